@@ -0,0 +1,125 @@
+package main
+
+import (
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// Highlight of milestone birthdays and anniversaries (-ms)
+const (
+    milestoneFgColor = fg_black
+    milestoneBgColor = bg_yellow
+)
+
+// anniversaryNames holds the traditional names of wedding anniversaries, by year.
+var anniversaryNames = map[int]string{
+    1:  "Paper",
+    5:  "Wood",
+    10: "Tin",
+    15: "Crystal",
+    20: "China",
+    25: "Silver",
+    30: "Pearl",
+    35: "Coral",
+    40: "Ruby",
+    45: "Sapphire",
+    50: "Golden",
+    55: "Emerald",
+    60: "Diamond",
+    65: "Blue Sapphire",
+    70: "Platinum",
+}
+
+// DayMilestone is a day count after a birthday or anniversary date worth an event (e.g. 10,000 days).
+type DayMilestone struct {
+    Days  int    // Days after the date
+    Label string // e.g. "10,000 days" or "500 weeks"
+    Key   string // As given on the command line (e.g. "10000d"), added to the event key of the milestone
+}
+
+// reDayMilestone matches one day-count milestone: N days (1000d) or N weeks (500w).
+var reDayMilestone = regexp.MustCompile(`^(\d+)([dw])$`)
+
+// ParseDayMilestones parses day-count milestones per event type, e.g.
+// "birthday:1000d,10000d,500w;anniversary:1000d". Types are matched case-insensitively.
+func ParseDayMilestones(spec string) (map[string][]DayMilestone, error) {
+    milestones := make(map[string][]DayMilestone)
+    for _, group := range strings.Split(spec, ";") {
+        if strings.TrimSpace(group) == "" {
+            continue
+        }
+        eventType, counts, found := strings.Cut(group, ":")
+        eventType = strings.ToLower(strings.TrimSpace(eventType))
+        if !found || eventType == "" {
+            return nil, fmt.Errorf("expected TYPE:COUNTS in '%s'", group)
+        }
+        for _, count := range strings.Split(counts, ",") {
+            matches := reDayMilestone.FindStringSubmatch(strings.TrimSpace(count))
+            if matches == nil {
+                return nil, fmt.Errorf("invalid milestone '%s', expected e.g. 1000d or 500w", strings.TrimSpace(count))
+            }
+            n, _ := strconv.Atoi(matches[1])
+            milestone := DayMilestone{Days: n, Label: formatThousands(n) + " days", Key: matches[0]}
+            if matches[2] == "w" {
+                milestone = DayMilestone{Days: n * 7, Label: formatThousands(n) + " weeks", Key: matches[0]}
+            }
+            milestones[eventType] = append(milestones[eventType], milestone)
+        }
+    }
+    return milestones, nil
+}
+
+// formatThousands formats n with comma thousands separators (e.g. 10000 -> "10,000").
+func formatThousands(n int) string {
+    s := strconv.Itoa(n)
+    for i := len(s) - 3; i > 0; i -= 3 {
+        s = s[:i] + "," + s[i:]
+    }
+    return s
+}
+
+// AnniversaryAge returns the age (or number of years) an anniversary event celebrates on its own date.
+func AnniversaryAge(e Event) int {
+    return e.Date.Year() - e.AnniDate.Year()
+}
+
+// Ordinal returns n with its English ordinal suffix (1st, 2nd, 3rd, 4th, 11th, 12th, 13th, 21st, 111th, ...).
+func Ordinal(n int) string {
+    suffix := "th"
+    if n%100 < 11 || n%100 > 13 {
+        switch n % 10 {
+        case 1:
+            suffix = "st"
+        case 2:
+            suffix = "nd"
+        case 3:
+            suffix = "rd"
+        }
+    }
+    return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// MilestoneLabel returns the label of a milestone birthday (every 10th) or anniversary
+// (those with a traditional name, e.g. "Silver anniversary"), or "" for other events.
+func MilestoneLabel(e Event) string {
+    if !e.IsAnniversary || e.AnniDate.IsZero() {
+        return ""
+    }
+    age := AnniversaryAge(e)
+    if age <= 0 {
+        return ""
+    }
+    switch strings.ToLower(e.Type) {
+    case "birthday":
+        if age%10 == 0 {
+            return "Round birthday"
+        }
+    case "anniversary":
+        if name, ok := anniversaryNames[age]; ok {
+            return name + " anniversary"
+        }
+    }
+    return ""
+}
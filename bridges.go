@@ -0,0 +1,147 @@
+package main
+
+import (
+    "sort"
+    "strings"
+    "time"
+)
+
+// Bridge is a suggestion of leave days that join non-working days into one longer break.
+type Bridge struct {
+    LeaveDays []time.Time // Working days to take off
+    Start     time.Time   // First day of the whole break
+    End       time.Time   // Last day of the whole break
+}
+
+// DaysOff returns the length of the whole break in days.
+func (b Bridge) DaysOff() int {
+    return int(b.End.Sub(b.Start).Hours()/24) + 1
+}
+
+// Efficiency returns the number of days off gained per leave day.
+func (b Bridge) Efficiency() float64 {
+    return float64(b.DaysOff()) / float64(len(b.LeaveDays))
+}
+
+// LeaveDaysString lists the leave days, collapsing consecutive days into ranges
+// (e.g. "Mon 29 Dec – Wed 31 Dec, Fri 02 Jan").
+func (b Bridge) LeaveDaysString() string {
+    const layout = "Mon 02 Jan"
+    var parts []string
+    for i := 0; i < len(b.LeaveDays); {
+        j := i
+        for j+1 < len(b.LeaveDays) && b.LeaveDays[j+1].Equal(b.LeaveDays[j].AddDate(0, 0, 1)) {
+            j++
+        }
+        if i == j {
+            parts = append(parts, b.LeaveDays[i].Format(layout))
+        } else {
+            parts = append(parts, b.LeaveDays[i].Format(layout)+" – "+b.LeaveDays[j].Format(layout))
+        }
+        i = j + 1
+    }
+    return strings.Join(parts, ", ")
+}
+
+// daySpan is a run of consecutive days that are either all working days or all days off.
+type daySpan struct {
+    off        bool
+    start, end time.Time
+}
+
+// FindBridges analyses the non-working days around year and returns every way of joining
+// two blocks of days off with at most maxLeave leave days, where the break contains at
+// least one holiday on a weekday and the first leave day falls in year.
+// The result is ranked by efficiency, then by the length of the break.
+func FindBridges(cfg Config, year int, holidays map[time.Time]Event, maxLeave int) []Bridge {
+    // Look a month beyond the year so breaks around New Year are measured completely
+    windowStart := time.Date(year-1, time.December, 1, 0, 0, 0, 0, time.UTC)
+    windowEnd   := time.Date(year+1, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+    var spans []daySpan
+    for d := windowStart; !d.After(windowEnd); d = d.AddDate(0, 0, 1) {
+        off := !IsWorkingDay(cfg, d, holidays)
+        if len(spans) > 0 && spans[len(spans)-1].off == off {
+            spans[len(spans)-1].end = d
+        } else {
+            spans = append(spans, daySpan{off: off, start: d, end: d})
+        }
+    }
+
+    var bridges []Bridge
+    for i := range spans {
+        if !spans[i].off {
+            continue
+        }
+        // Take off one or more working-day runs following this block, as long as each
+        // run is followed by another block of days off.
+        var leaveDays []time.Time
+        for j := i + 1; j+1 < len(spans); j += 2 {
+            for d := spans[j].start; !d.After(spans[j].end); d = d.AddDate(0, 0, 1) {
+                leaveDays = append(leaveDays, d)
+            }
+            if len(leaveDays) > maxLeave {
+                break
+            }
+            bridge := Bridge{
+                LeaveDays: append([]time.Time(nil), leaveDays...),
+                Start:     spans[i].start,
+                End:       spans[j+1].end,
+            }
+            if bridge.LeaveDays[0].Year() == year && containsWeekdayHoliday(cfg, bridge, holidays) {
+                bridges = append(bridges, bridge)
+            }
+        }
+    }
+
+    sort.SliceStable(bridges, func(a, b int) bool {
+        if bridges[a].Efficiency() != bridges[b].Efficiency() {
+            return bridges[a].Efficiency() > bridges[b].Efficiency()
+        }
+        if bridges[a].DaysOff() != bridges[b].DaysOff() {
+            return bridges[a].DaysOff() > bridges[b].DaysOff()
+        }
+        return bridges[a].Start.Before(bridges[b].Start)
+    })
+    return bridges
+}
+
+// containsWeekdayHoliday reports whether the break includes a holiday not falling on a weekend,
+// so plain weekend-to-weekend leave is not suggested as a bridge.
+func containsWeekdayHoliday(cfg Config, b Bridge, holidays map[time.Time]Event) bool {
+    for d := b.Start; !d.After(b.End); d = d.AddDate(0, 0, 1) {
+        if _, isHoliday := holidays[d]; isHoliday && !cfg.WeekendDays[d.Weekday()] {
+            return true
+        }
+    }
+    return false
+}
+
+// SelectBridges picks bridges in ranked order, skipping those overlapping an already picked
+// break, until limit suggestions are chosen or the leave budget is used up.
+// A budget or limit of 0 means no restriction.
+func SelectBridges(bridges []Bridge, budget, limit int) []Bridge {
+    var selected []Bridge
+    used := 0
+    for _, b := range bridges {
+        if limit > 0 && len(selected) >= limit {
+            break
+        }
+        if budget > 0 && used+len(b.LeaveDays) > budget {
+            continue
+        }
+        overlaps := false
+        for _, s := range selected {
+            if !b.End.Before(s.Start) && !s.End.Before(b.Start) {
+                overlaps = true
+                break
+            }
+        }
+        if overlaps {
+            continue
+        }
+        selected = append(selected, b)
+        used += len(b.LeaveDays)
+    }
+    return selected
+}
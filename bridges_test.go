@@ -0,0 +1,63 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestFindAndSelectBridges(t *testing.T) {
+    // Holidays on Thursday 1 October and Tuesday 8 December 2026
+    holidays := map[time.Time]Event{
+        date(2026, time.October, 1):  {Date: date(2026, time.October, 1), Description: "Thursday holiday"},
+        date(2026, time.December, 8): {Date: date(2026, time.December, 8), Description: "Tuesday holiday"},
+    }
+    cfg := defaultConfig(date(2026, time.January, 1))
+
+    bridges := FindBridges(cfg, 2026, holidays, 4)
+    want := []struct {
+        start, end time.Time
+        leaveDays  int
+    }{
+        {date(2026, time.October, 1), date(2026, time.October, 4), 1},   // Friday off, efficiency 4
+        {date(2026, time.December, 5), date(2026, time.December, 8), 1}, // Monday off, efficiency 4
+        {date(2026, time.September, 26), date(2026, time.October, 4), 4},
+        {date(2026, time.December, 5), date(2026, time.December, 13), 4},
+        {date(2026, time.September, 26), date(2026, time.October, 1), 3},
+        {date(2026, time.December, 8), date(2026, time.December, 13), 3},
+    }
+    if len(bridges) != len(want) {
+        t.Fatalf("FindBridges(2026, max 4) = %d bridges, want %d", len(bridges), len(want))
+    }
+    for i, w := range want {
+        b := bridges[i]
+        if !b.Start.Equal(w.start) || !b.End.Equal(w.end) || len(b.LeaveDays) != w.leaveDays {
+            t.Errorf("bridge %d = %s – %s with %d leave days, want %s – %s with %d", i, b.Start.Format("2006-01-02"), b.End.Format("2006-01-02"), len(b.LeaveDays), w.start.Format("2006-01-02"), w.end.Format("2006-01-02"), w.leaveDays)
+        }
+    }
+    if n := len(FindBridges(cfg, 2026, holidays, 1)); n != 2 {
+        t.Errorf("FindBridges(2026, max 1) = %d bridges, want 2", n)
+    }
+
+    tests := []struct {
+        budget, limit int
+        wantStarts    []time.Time
+    }{
+        {0, 0, []time.Time{date(2026, time.October, 1), date(2026, time.December, 5)}}, // The others overlap these
+        {0, 1, []time.Time{date(2026, time.October, 1)}},
+        {1, 0, []time.Time{date(2026, time.October, 1)}},
+    }
+    for _, tt := range tests {
+        selected := SelectBridges(bridges, tt.budget, tt.limit)
+        ok := len(selected) == len(tt.wantStarts)
+        for i := 0; ok && i < len(selected); i++ {
+            ok = selected[i].Start.Equal(tt.wantStarts[i])
+        }
+        if !ok {
+            var starts []string
+            for _, b := range selected {
+                starts = append(starts, b.Start.Format("2006-01-02"))
+            }
+            t.Errorf("SelectBridges(budget %d, limit %d) starts %v, want %v", tt.budget, tt.limit, starts, tt.wantStarts)
+        }
+    }
+}
@@ -9,6 +9,10 @@ import (
 // getDisplayMonthYear determines the target month and year for the calendar display
 // based on the configuration (either month/year or year/week).
 func getDisplayMonthYear(cfg Config) (time.Month, int) {
+    if !cfg.FromDate.IsZero() {
+        // An explicit date range starts the display at the month containing its first day
+        return cfg.FromDate.Month(), cfg.FromDate.Year()
+    }
     if cfg.Week > 0 && cfg.Year > 0 {
         // Calculate month/year from year/week input
         firstDayOfWeek, err := GetFirstDayOfISOWeek(cfg.Year, cfg.Week)
@@ -23,3 +27,31 @@ func getDisplayMonthYear(cfg Config) (time.Month, int) {
     return cfg.Month, cfg.Year
 }
 
+
+// getDisplayRange returns the first and the last day of the displayed period.
+// If an explicit range was given (-from/-to) it is returned as is, otherwise the range
+// covers cfg.NumMonths whole months starting at startMonth/startYear.
+func getDisplayRange(cfg Config, startMonth time.Month, startYear int) (time.Time, time.Time) {
+    if !cfg.FromDate.IsZero() && !cfg.ToDate.IsZero() {
+        return cfg.FromDate, cfg.ToDate
+    }
+    startDate := time.Date(startYear, startMonth, 1, 0, 0, 0, 0, cfg.TargetTime.Location())
+    endDate   := startDate.AddDate(0, cfg.NumMonths, -1) // Last day of the end month
+    return startDate, endDate
+}
+
+// loadEventsForYears loads the events of every year from fromYear to toYear (inclusive).
+// The events file is parsed once; if it cannot be read, only a warning is printed.
+func loadEventsForYears(cfg Config, fromYear, toYear int) []Event {
+    file, err := loadEventFile(cfg)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Warning: Could not load events: %v\n", err)
+        // Continue, don't exit, just print a warning
+        return nil
+    }
+    var allEvents []Event
+    for year := fromYear; year <= toYear; year++ {
+        allEvents = append(allEvents, file.eventsForYear(cfg, year)...)
+    }
+    return allEvents
+}
@@ -0,0 +1,560 @@
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+    "regexp"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// commands maps subcommand names (first command-line argument) to their handlers.
+// A handler receives the remaining arguments and returns the process exit code.
+var commands = map[string]func(args []string) int{
+    "workdays":    runWorkdays,
+    "addworkdays": runAddWorkdays,
+    "bridges":     runBridges,
+    "when":        runWhen,
+    "date":        runDate,
+}
+
+// reNegativeNumber matches arguments like "-10" or "-3w" (a date step) which are values, not flags.
+var reNegativeNumber = regexp.MustCompile(`^-\d+(?:d|w|m|y|bd)?$`)
+
+// commonFlags holds the flags shared by all subcommands.
+type commonFlags struct {
+    cfg     *Config
+    weekend *string
+    tags    *string
+}
+
+// registerCommonFlags defines the shared subcommand flags on fs, writing into cfg.
+func registerCommonFlags(fs *flag.FlagSet, cfg *Config) *commonFlags {
+    fs.StringVar(&cfg.EventsFile, "f", cfg.EventsFile, "Path to the events file.")
+    fs.BoolVar(&cfg.StrictNth, "strict", cfg.StrictNth, "MM/DOW#5 rules skip months without a 5th occurrence instead of using the last one.")
+    fs.StringVar(&cfg.LeapPolicy, "leap", cfg.LeapPolicy, "Where February 29th birthdays and rules fall in common years: 'feb28', 'mar1' or 'skip'.")
+    return &commonFlags{
+        cfg:     cfg,
+        weekend: fs.String("weekend", "sat,sun", "Comma-separated weekend days, e.g. 'fri,sat'."),
+        tags:    fs.String("t", "", "Comma-separated event types counted as non-working days (also skipped by bd-/bd+ rules), e.g. 'ie'."),
+    }
+}
+
+// apply validates the parsed shared flags and stores them in the configuration.
+func (cf *commonFlags) apply() error {
+    weekendDays, err := ParseWeekdaySet(*cf.weekend)
+    if err != nil {
+        return fmt.Errorf("invalid -weekend value: %w", err)
+    }
+    cf.cfg.WeekendDays = weekendDays
+    leapPolicy, err := ParseLeapPolicy(cf.cfg.LeapPolicy)
+    if err != nil {
+        return fmt.Errorf("invalid -leap value: %w", err)
+    }
+    cf.cfg.LeapPolicy = leapPolicy
+    cf.cfg.HolidayTags = ParseTagList(*cf.tags)
+    return nil
+}
+
+// parseCommandArgs parses flags that may be mixed with positional arguments
+// (e.g. "addworkdays 2025-12-19 -t ie 10") and returns the positional ones.
+func parseCommandArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+    var positional []string
+    for {
+        for len(args) > 0 && reNegativeNumber.MatchString(args[0]) {
+            positional = append(positional, args[0])
+            args = args[1:]
+        }
+        if err := fs.Parse(args); err != nil {
+            return nil, err
+        }
+        args = fs.Args()
+        if len(args) == 0 {
+            return positional, nil
+        }
+        positional = append(positional, args[0])
+        args = args[1:]
+    }
+}
+
+// formatDate formats a date the same way the event list does (e.g. "Fri, 19 Dec 2025").
+func formatDate(t time.Time) string {
+    return t.Format("Mon, 02 Jan 2006")
+}
+
+// runWorkdays implements "workdays": the number of working days in a date range.
+func runWorkdays(args []string) int {
+    cfg := defaultConfig(time.Now())
+    fs := flag.NewFlagSet("workdays", flag.ContinueOnError)
+    common := registerCommonFlags(fs, &cfg)
+    fromFlag := fs.String("from", "", "First day of the range (YYYY-MM-DD) (default: today).")
+    toFlag   := fs.String("to",   "", "Last day of the range (YYYY-MM-DD).")
+    fs.Usage = func() {
+        fmt.Fprintf(os.Stderr, "Usage of %s workdays:\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, " %s workdays -from YYYY-MM-DD -to YYYY-MM-DD [options]\n\nOptions:\n", os.Args[0])
+        fs.PrintDefaults()
+        fmt.Fprintf(os.Stderr, "\n\033[1mExamples:\033[0m\n")
+        fmt.Fprintf(os.Stderr, "  %s workdays -from 2025-12-01 -to 2025-12-31 -t ie\n", os.Args[0])
+    }
+    if _, err := parseCommandArgs(fs, args); err != nil {
+        return 2
+    }
+    if err := common.apply(); err != nil {
+        fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+        return 1
+    }
+
+    fromDate := dateKey(cfg.TargetTime)
+    if *fromFlag != "" {
+        d, err := ParseDateArg(*fromFlag)
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "Error: Invalid -from value: %v\n", err)
+            return 1
+        }
+        fromDate = d
+    }
+    if *toFlag == "" {
+        fmt.Fprintf(os.Stderr, "Error: workdays requires -to.\n")
+        fs.Usage()
+        return 1
+    }
+    toDate, err := ParseDateArg(*toFlag)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Error: Invalid -to value: %v\n", err)
+        return 1
+    }
+    if toDate.Before(fromDate) {
+        fmt.Fprintf(os.Stderr, "Error: -to date %s is before -from date %s.\n", toDate.Format("2006-01-02"), fromDate.Format("2006-01-02"))
+        return 1
+    }
+
+    events := loadEventsForYears(cfg, fromDate.Year(), toDate.Year())
+    holidays := HolidaySet(events, cfg.HolidayTags)
+    workDays := CountWorkingDays(cfg, fromDate, toDate, holidays)
+
+    totalDays, weekendDays := 0, 0
+    var holidaysInRange []Event
+    for d := fromDate; !d.After(toDate); d = d.AddDate(0, 0, 1) {
+        totalDays++
+        if cfg.WeekendDays[d.Weekday()] {
+            weekendDays++
+        } else if ev, isHoliday := holidays[d]; isHoliday {
+            holidaysInRange = append(holidaysInRange, ev)
+        }
+    }
+
+    fmt.Printf("%s%d%s working day%s from %s to %s\n", style_bold, workDays, style_reset, pluralS(workDays), formatDate(fromDate), formatDate(toDate))
+    fmt.Printf(" %d day%s, %d weekend day%s, %d holiday%s\n", totalDays, pluralS(totalDays), weekendDays, pluralS(weekendDays), len(holidaysInRange), pluralS(len(holidaysInRange)))
+    for _, ev := range holidaysInRange {
+        fmt.Printf(" %s%s%s%s  %s\n", ev.DisplayColor, ev.DisplayBgColor, formatDate(ev.Date), style_reset, ev.Description)
+    }
+    return 0
+}
+
+// runAddWorkdays implements "addworkdays": the date N working days after (or before) a date.
+func runAddWorkdays(args []string) int {
+    cfg := defaultConfig(time.Now())
+    fs := flag.NewFlagSet("addworkdays", flag.ContinueOnError)
+    common := registerCommonFlags(fs, &cfg)
+    fs.Usage = func() {
+        fmt.Fprintf(os.Stderr, "Usage of %s addworkdays:\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, " %s addworkdays YYYY-MM-DD N [options]\n\nOptions:\n", os.Args[0])
+        fs.PrintDefaults()
+        fmt.Fprintf(os.Stderr, "\n\033[1mExamples:\033[0m\n")
+        fmt.Fprintf(os.Stderr, "  %s addworkdays 2025-12-19 10 -t ie\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, "  %s addworkdays 2026-01-05 -3 -t ie\n", os.Args[0])
+    }
+    positional, err := parseCommandArgs(fs, args)
+    if err != nil {
+        return 2
+    }
+    if err := common.apply(); err != nil {
+        fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+        return 1
+    }
+    if len(positional) != 2 {
+        fmt.Fprintf(os.Stderr, "Error: addworkdays requires a date and a number of working days.\n")
+        fs.Usage()
+        return 1
+    }
+    startDate, err := ParseDateArg(positional[0])
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+        return 1
+    }
+    n, err := strconv.Atoi(positional[1])
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Error: Invalid number of working days '%s'.\n", positional[1])
+        return 1
+    }
+
+    spanYears := workdaySpanYears(cfg, n)
+    fromYear, toYear := startDate.Year(), startDate.Year()+spanYears
+    if n < 0 {
+        fromYear, toYear = startDate.Year()-spanYears, startDate.Year()
+    }
+    events := loadEventsForYears(cfg, fromYear, toYear)
+    holidays := HolidaySet(events, cfg.HolidayTags)
+
+    result, err := AddWorkingDays(cfg, startDate, n, holidays)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+        return 1
+    }
+    fmt.Printf("%s %+d working day%s = %s%s%s\n", formatDate(startDate), n, pluralS(n), style_bold, formatDate(result), style_reset)
+    return 0
+}
+
+// runBridges implements "bridges": leave days that make the longest breaks around holidays.
+func runBridges(args []string) int {
+    cfg := defaultConfig(time.Now())
+    fs := flag.NewFlagSet("bridges", flag.ContinueOnError)
+    common := registerCommonFlags(fs, &cfg)
+    yearFlag   := fs.Int("year",   cfg.Year, "Year to plan.")
+    budgetFlag := fs.Int("budget", 0, "Total number of leave days available (0: no limit).")
+    maxFlag    := fs.Int("max",    5, "Maximum number of leave days for a single break.")
+    countFlag  := fs.Int("n",      10, "Maximum number of suggestions (0: no limit).")
+    fs.Usage = func() {
+        fmt.Fprintf(os.Stderr, "Usage of %s bridges:\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, " %s bridges [options]\n\nOptions:\n", os.Args[0])
+        fs.PrintDefaults()
+        fmt.Fprintf(os.Stderr, "\n\033[1mExamples:\033[0m\n")
+        fmt.Fprintf(os.Stderr, "  %s bridges -year 2026 -t ie\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, "  %s bridges -year 2026 -t ie -budget 8\n", os.Args[0])
+    }
+    if _, err := parseCommandArgs(fs, args); err != nil {
+        return 2
+    }
+    if err := common.apply(); err != nil {
+        fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+        return 1
+    }
+    if len(cfg.HolidayTags) == 0 {
+        fmt.Fprintf(os.Stderr, "Error: bridges requires -t with the event types of the public holidays.\n")
+        fs.Usage()
+        return 1
+    }
+    if *maxFlag < 1 {
+        fmt.Fprintf(os.Stderr, "Error: Invalid -max value %d. Must be at least 1.\n", *maxFlag)
+        return 1
+    }
+
+    events := loadEventsForYears(cfg, *yearFlag-1, *yearFlag+1)
+    holidays := HolidaySet(events, cfg.HolidayTags)
+    bridges := SelectBridges(FindBridges(cfg, *yearFlag, holidays, *maxFlag), *budgetFlag, *countFlag)
+
+    if len(bridges) == 0 {
+        fmt.Printf("No bridge days found for %d.\n", *yearFlag)
+        return 0
+    }
+    fmt.Printf("%sBridge days for %d:%s\n", style_bold, *yearFlag, style_reset)
+    totalLeave, totalOff := 0, 0
+    for i, b := range bridges {
+        fmt.Printf(" %2d. Take %s%s%s to get %s%d days off%s (%s – %s) for %d leave day%s %s(%.1f per leave day)%s\n",
+            i+1, fg_green, b.LeaveDaysString(), style_reset,
+            style_bold, b.DaysOff(), style_reset,
+            b.Start.Format("Mon 02 Jan"), b.End.Format("Mon 02 Jan 2006"),
+            len(b.LeaveDays), pluralS(len(b.LeaveDays)), fg_blue, b.Efficiency(), style_reset)
+        totalLeave += len(b.LeaveDays)
+        totalOff += b.DaysOff()
+    }
+    fmt.Printf(" Total: %d leave day%s for %d days off\n", totalLeave, pluralS(totalLeave), totalOff)
+    return 0
+}
+
+// runWhen implements "when": the next dates of the events matching a description, or of an ad-hoc rule.
+func runWhen(args []string) int {
+    cfg := defaultConfig(time.Now())
+    fs := flag.NewFlagSet("when", flag.ContinueOnError)
+    common := registerCommonFlags(fs, &cfg)
+    ruleFlag  := fs.String("rule",  "", "Event rule to evaluate instead of searching the events file, e.g. '11/4#4'.")
+    countFlag := fs.Int("n",        0,  "Number of occurrences to show (default: 1, or all within -years).")
+    yearsFlag := fs.Int("years",    0,  "Number of years to look ahead (default: until -n occurrences are found, at most 100 years).")
+    fs.Usage = func() {
+        fmt.Fprintf(os.Stderr, "Usage of %s when:\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, " %s when \"DESCRIPTION\" [options]\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, " %s when -rule RULE [options]\n\nOptions:\n", os.Args[0])
+        fs.PrintDefaults()
+        fmt.Fprintf(os.Stderr, "\n\033[1mExamples:\033[0m\n")
+        fmt.Fprintf(os.Stderr, "  %s when \"Easter Monday\"\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, "  %s when -rule 12/25 -years 5\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, "  %s when --rule \"11/4#4\" --years 10\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, "  %s when -rule \"12/25 bd+\" -years 3 -t ie\n", os.Args[0])
+    }
+    positional, err := parseCommandArgs(fs, args)
+    if err != nil {
+        return 2
+    }
+    if err := common.apply(); err != nil {
+        fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+        return 1
+    }
+    query := strings.TrimSpace(strings.Join(positional, " "))
+    if (query == "") == (*ruleFlag == "") {
+        fmt.Fprintf(os.Stderr, "Error: when requires either a description or -rule.\n")
+        fs.Usage()
+        return 1
+    }
+    if *countFlag < 0 || *yearsFlag < 0 {
+        fmt.Fprintf(os.Stderr, "Error: -n and -years must not be negative.\n")
+        return 1
+    }
+
+    // Without -years, search until -n (default 1) occurrences are found; with -years, list every
+    // occurrence in that many years unless -n limits them.
+    count, years := *countFlag, *yearsFlag
+    if years == 0 {
+        years = 100
+        if count == 0 {
+            count = 1
+        }
+    }
+
+    today := dateKey(cfg.TargetTime)
+    lastDay := today.AddDate(years, 0, -1)
+
+    // Parsed once for all years; a -rule may refer to @id events of the events file
+    file, err := loadEventFile(cfg)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+        return 1
+    }
+    // A -rule is resolved like a line of the file: it takes the same qualifiers (e.g. "12/25 bd+")
+    // and its bd-/bd+ moves skip the file's holidays. Its ID cannot clash with the keys of the file,
+    // which are @id or rules, so exclusions and overrides of the file do not apply to it.
+    var rule eventDefinition
+    if *ruleFlag != "" {
+        rule = eventDefinition{id: "-rule", originalDateStr: *ruleFlag, description: *ruleFlag, eventType: "default"}
+        err := parseRuleQualifiers(&rule, *ruleFlag)
+        if err == nil {
+            _, err = definitionEvents(cfg, rule, today.Year(), file.refs)
+        }
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "Error: Invalid -rule value: %v\n", err)
+            return 1
+        }
+        file.definitions = append(file.definitions, rule)
+    }
+
+    var found []Event
+    seen := make(map[string]bool)
+    for year := today.Year(); year <= lastDay.Year() && (count == 0 || len(found) < count); year++ {
+        var candidates []Event
+        for _, ev := range file.eventsForYear(cfg, year) {
+            if (*ruleFlag != "" && ev.ID == rule.key()) || (*ruleFlag == "" && strings.Contains(strings.ToLower(ev.Description), strings.ToLower(query))) {
+                candidates = append(candidates, ev)
+            }
+        }
+
+        sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Date.Before(candidates[j].Date) })
+        for _, ev := range candidates {
+            d := dateKey(ev.Date)
+            key := d.Format("2006-01-02") + "::" + ev.Description
+            if d.Before(today) || d.After(lastDay) || seen[key] || (count > 0 && len(found) >= count) {
+                continue
+            }
+            seen[key] = true
+            found = append(found, ev)
+        }
+    }
+
+    if len(found) == 0 {
+        if *ruleFlag != "" {
+            fmt.Printf("No occurrence of %s in the next %d year%s.\n", *ruleFlag, years, pluralS(years))
+        } else {
+            fmt.Printf("No event matching '%s' in the next %d year%s.\n", query, years, pluralS(years))
+        }
+        return 0
+    }
+    for _, ev := range found {
+        fmt.Printf(" %s%s%s  %s %s(%s)%s\n", style_bold, formatDate(ev.Date), style_reset, ev.Description, fg_blue, formatDaysFrom(today, ev.Date), style_reset)
+    }
+    return 0
+}
+
+// reDuration matches a date arithmetic step: +10d, -3w, 2m, +1y or +5bd (working days).
+var reDuration = regexp.MustCompile(`^([+-]?\d+)(d|w|m|y|bd)$`)
+
+// runDate implements "date": differences between dates, date arithmetic and date conversions.
+func runDate(args []string) int {
+    cfg := defaultConfig(time.Now())
+    fs := flag.NewFlagSet("date", flag.ContinueOnError)
+    common := registerCommonFlags(fs, &cfg)
+    fs.Usage = func() {
+        fmt.Fprintf(os.Stderr, "Usage of %s date:\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, " %s date diff DATE DATE [options]       Days, weeks and working days between two dates\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, " %s date add DATE STEP... [options]     Add steps: +Nd, +Nw, +Nm, +Ny or +Nbd (working days); - subtracts\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, " %s date info [DATE]                    ISO week, day of year, Hebrew, Hijri and Chinese date\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, "DATE is YYYY-MM-DD, DD-MM-YYYY or 'today'.\n\nOptions:\n")
+        fs.PrintDefaults()
+        fmt.Fprintf(os.Stderr, "\n\033[1mExamples:\033[0m\n")
+        fmt.Fprintf(os.Stderr, "  %s date diff 2025-12-19 2026-01-05 -t ie\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, "  %s date add today +2w +3bd -t ie\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, "  %s date info 2025-12-19\n", os.Args[0])
+    }
+    positional, err := parseCommandArgs(fs, args)
+    if err != nil {
+        return 2
+    }
+    if err := common.apply(); err != nil {
+        fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+        return 1
+    }
+    if len(positional) == 0 {
+        fmt.Fprintf(os.Stderr, "Error: date requires an operation: diff, add or info.\n")
+        fs.Usage()
+        return 1
+    }
+
+    // Date arguments, with "today" for the current date
+    var dates []time.Time
+    var steps []string
+    for i, arg := range positional[1:] {
+        if positional[0] == "add" && i > 0 {
+            steps = append(steps, arg)
+            continue
+        }
+        d := dateKey(cfg.TargetTime)
+        if arg != "today" {
+            if d, err = ParseDateArg(arg); err != nil {
+                fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+                return 1
+            }
+        }
+        dates = append(dates, d)
+    }
+
+    switch positional[0] {
+    case "diff":
+        if len(dates) != 2 {
+            fmt.Fprintf(os.Stderr, "Error: date diff requires two dates.\n")
+            fs.Usage()
+            return 1
+        }
+        from, to := dates[0], dates[1]
+        days := fixedFromDate(to) - fixedFromDate(from)
+
+        // Working days after the first date up to the second, negative if it is earlier
+        events := loadEventsForYears(cfg, min(from.Year(), to.Year()), max(from.Year(), to.Year()))
+        holidays := HolidaySet(events, cfg.HolidayTags)
+        workDays := 0
+        if days > 0 {
+            workDays = CountWorkingDays(cfg, from.AddDate(0, 0, 1), to, holidays)
+        } else if days < 0 {
+            workDays = -CountWorkingDays(cfg, to, from.AddDate(0, 0, -1), holidays)
+        }
+
+        fmt.Printf("From %s to %s:\n", formatDate(from), formatDate(to))
+        fmt.Printf(" %s%d%s day%s (%d week%s %d day%s)\n", style_bold, days, style_reset, pluralS(days), abs(days)/7, pluralS(abs(days)/7), abs(days)%7, pluralS(abs(days)%7))
+        fmt.Printf(" %s%d%s working day%s\n", style_bold, workDays, style_reset, pluralS(workDays))
+    case "add":
+        if len(dates) != 1 || len(steps) == 0 {
+            fmt.Fprintf(os.Stderr, "Error: date add requires a date and at least one step.\n")
+            fs.Usage()
+            return 1
+        }
+        result := dates[0]
+        var file *eventFile
+        var events []Event
+        loadedYears := make(map[int]bool)
+        for _, step := range steps {
+            matches := reDuration.FindStringSubmatch(step)
+            if matches == nil {
+                fmt.Fprintf(os.Stderr, "Error: Invalid step '%s', expected e.g. +10d, -3w, +2m, +1y or +5bd.\n", step)
+                return 1
+            }
+            n, _ := strconv.Atoi(matches[1])
+            switch matches[2] {
+            case "d":
+                result = result.AddDate(0, 0, n)
+            case "w":
+                result = result.AddDate(0, 0, n*7)
+            case "m":
+                result = AddMonthsClamped(result, n)
+            case "y":
+                result = AddMonthsClamped(result, n*12)
+            case "bd":
+                if file == nil {
+                    if file, err = loadEventFile(cfg); err != nil {
+                        fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+                        return 1
+                    }
+                }
+                // Holidays of the years this step can reach from the date so far
+                spanYears := workdaySpanYears(cfg, n)
+                for year := result.Year() - spanYears; year <= result.Year()+spanYears; year++ {
+                    if !loadedYears[year] {
+                        loadedYears[year] = true
+                        events = append(events, file.eventsForYear(cfg, year)...)
+                    }
+                }
+                holidays := HolidaySet(events, cfg.HolidayTags)
+                if result, err = AddWorkingDays(cfg, result, n, holidays); err != nil {
+                    fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+                    return 1
+                }
+            }
+        }
+        fmt.Printf("%s %s = %s%s%s\n", formatDate(dates[0]), strings.Join(steps, " "), style_bold, formatDate(result), style_reset)
+    case "info":
+        if len(dates) == 0 {
+            dates = append(dates, dateKey(cfg.TargetTime))
+        }
+        if len(dates) != 1 {
+            fmt.Fprintf(os.Stderr, "Error: date info takes at most one date.\n")
+            return 1
+        }
+        d := dates[0]
+        isoYear, isoWeek := d.ISOWeek()
+        isoDay := (int(d.Weekday())+6)%7 + 1
+        daysInYear := time.Date(d.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+        hYear, hMonth, hDay := HebrewFromDate(d)
+        iYear, iMonth, iDay := IslamicFromDate(d)
+        cMonth, cLeap, cDay := ChineseFromDate(d)
+        chineseMonth := fmt.Sprintf("month %d", cMonth)
+        if cLeap {
+            chineseMonth = fmt.Sprintf("leap month %d", cMonth)
+        }
+
+        fmt.Printf("%s%s%s (%s)\n", style_bold, formatDate(d), style_reset, formatDaysFrom(dateKey(cfg.TargetTime), d))
+        fmt.Printf(" ISO week:    %d-W%02d-%d\n", isoYear, isoWeek, isoDay)
+        fmt.Printf(" Day of year: %d of %d (%d day%s left)\n", d.YearDay(), daysInYear, daysInYear-d.YearDay(), pluralS(daysInYear-d.YearDay()))
+        fmt.Printf(" Hebrew:      %d %s %d\n", hDay, HebrewMonthName(hYear, hMonth), hYear)
+        fmt.Printf(" Hijri:       %d %s %d (tabular)\n", iDay, IslamicMonthName(iMonth), iYear)
+        fmt.Printf(" Chinese:     day %d of %s, year of the %s\n", cDay, chineseMonth, ChineseZodiac(d))
+    default:
+        fmt.Fprintf(os.Stderr, "Error: Unknown date operation '%s'. Must be diff, add or info.\n", positional[0])
+        fs.Usage()
+        return 1
+    }
+    return 0
+}
+
+// formatDaysFrom describes date relative to today, e.g. "today", "in 5 days" or "3 days ago".
+func formatDaysFrom(today, date time.Time) string {
+    days := fixedFromDate(date) - fixedFromDate(today)
+    switch {
+    case days == 0:
+        return "today"
+    case days > 0:
+        return fmt.Sprintf("in %d day%s", days, pluralS(days))
+    }
+    return fmt.Sprintf("%d day%s ago", -days, pluralS(days))
+}
+
+// workdaySpanYears returns how many years n working days can span, even with a long weekend.
+func workdaySpanYears(cfg Config, n int) int {
+    workDaysPerWeek := max(7-len(cfg.WeekendDays), 1)
+    return (abs(n)*7/workDaysPerWeek)/300 + 1
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+    if n < 0 {
+        return -n
+    }
+    return n
+}
@@ -43,13 +43,48 @@ type Config struct {
     Year        int
     Month       time.Month
     Week        int // If Week > 0, it's used with Year to determine month
-    MondayFirst bool
+    MondayFirst bool         // Kept for -monday; WeekStart is what the views use
+    WeekStart   time.Weekday // First day of the week in the month view
+    WeekendDays map[time.Weekday]bool // Days shown (and counted) as weekend
     EventsFile  string
     ShowWeekNum bool
     TargetTime  time.Time // Current time for age/countdown calculations
     NumMonths   int       // Number of months to display (1, 3, 6, 12)
     NumColumns  int       // Number of months to display (1, 2, 3, 3, 6, 12)
     DisplayMode string    // "calendar", "events", or "both"
+    FromDate    time.Time // Start of an explicit date range (-from); zero if not set
+    ToDate      time.Time // End of an explicit date range (-to); zero if not set
+    HolidayTags []string  // Event types treated as non-working days (e.g. "ie")
+    ShowSummary bool      // Show a working-day summary line under each month
+    ShowZodiac  bool      // Show the Chinese zodiac animal in the month header
+    ShowMoon    bool      // Show a new/full moon glyph column in the month view
+    HasLocation bool      // True if both Latitude and Longitude were given
+    Latitude    float64   // Degrees, north positive (for sunrise/sunset)
+    Longitude   float64   // Degrees, east positive (for sunrise/sunset)
+    ShowSun     bool      // Show sunrise/sunset for every listed event day
+    StrictNth   bool      // MM/DOW#5 has no date in months without a 5th occurrence (instead of the last one)
+    LeapPolicy  string    // Where February 29th dates fall in common years: LeapFeb28, LeapMar1 or LeapSkip
+    Milestones  bool      // Label and highlight round birthdays and named (e.g. silver) anniversaries
+    DayMilestones map[string][]DayMilestone // Day-count milestones per event type (lower case), e.g. 10,000 days
+}
+
+// defaultConfig returns the configuration used when no flags are given.
+func defaultConfig(currentTime time.Time) Config {
+    return Config{
+        Year:        currentTime.Year(),
+        Month:       currentTime.Month(),
+        Week:        0, // Will be checked if > 0
+        MondayFirst: true,
+        WeekStart:   time.Monday,
+        WeekendDays: map[time.Weekday]bool{time.Saturday: true, time.Sunday: true},
+        EventsFile:  "events.txt", // Default events file name
+        ShowWeekNum: true,
+        TargetTime:  currentTime, // Reference time for age/countdown
+        NumMonths:   1,           // Default to showing 1 month
+        NumColumns:  3,
+        DisplayMode: DisplayBoth, // Default to showing both calendar and events
+        LeapPolicy:  LeapMar1,
+    }
 }
 
 // Event represents a calendar event
@@ -66,6 +101,10 @@ type Event struct {
     DisplayColor     string    // ANSI foreground color code for highlighting this event type
     DisplayBgColor   string    // ANSI background color code for highlighting this event type
     Emoji            string    // New field: Specific emoji for this event, if provided
+    TimeSpec         string    // Time of day from an "at" qualifier: "HH:MM", "sunrise" or "sunset", optionally with "+Nm"/"-Nm"
+    Observed         string    // Substitution policy from an "observed" qualifier: "next", "nearest" or "roll"
+    BusinessDay      int       // From a "bd-"/"bd+" qualifier: -1/+1 moves the event to the previous/next working day
+    ID               string    // Key for exclusions and overrides: "@id" if the rule has an ID prefix, otherwise the rule itself
 }
 
 // getDefaultEmoji returns a default emoji for a given event type.
@@ -88,6 +127,16 @@ func getDefaultEmoji(tag string) string {
         return "🏖️"
     case "church":
         return "✝️"
+    case "orthodox":
+        return "☦️"
+    case "jewish":
+        return "✡️"
+    case "islamic":
+        return "☪️"
+    case "chinese":
+        return "🏮"
+    case "astro":
+        return "🔭"
     case "fun":
         return "🎉"
     default:
@@ -1,7 +1,12 @@
 package main
 
 import (
+    "errors"
     "fmt"
+    "math"
+    "regexp"
+    "strconv"
+    "strings"
     "time"
 )
 
@@ -26,13 +31,65 @@ func CalculateEaster(year int) time.Time {
     return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
 }
 
+// ErrNoSuchDate reports that a valid rule does not produce a date in a given month or year
+// (e.g. a strict 5th Monday in a month with only four). Callers treat it as "no occurrence".
+var ErrNoSuchDate = errors.New("no such date")
+
+// WeekdayRelativeTo returns the first date with weekday wd after (">"), on or after (">="),
+// before ("<") or on or before ("<=") the given date.
+func WeekdayRelativeTo(date time.Time, op string, wd time.Weekday) (time.Time, error) {
+    switch op {
+    case ">", ">=":
+        days := (int(wd) - int(date.Weekday()) + 7) % 7
+        if days == 0 && op == ">" {
+            days = 7 // Strictly after: the same weekday a week later
+        }
+        return date.AddDate(0, 0, days), nil
+    case "<", "<=":
+        days := (int(date.Weekday()) - int(wd) + 7) % 7
+        if days == 0 && op == "<" {
+            days = 7 // Strictly before: the same weekday a week earlier
+        }
+        return date.AddDate(0, 0, -days), nil
+    }
+    return time.Time{}, fmt.Errorf("invalid weekday operator '%s'", op)
+}
+
+// CalculateOrthodoxEaster calculates the date of Orthodox Easter Sunday for a given year.
+// Easter is computed in the Julian calendar (Meeus' Julian algorithm) and converted to Gregorian.
+// Returns the date in UTC.
+func CalculateOrthodoxEaster(year int) time.Time {
+    a := year % 4
+    b := year % 7
+    c := year % 19
+    d := (19*c + 15) % 30
+    e := (2*a + 4*b - d + 34) % 7
+    month := (d + e + 114) / 31
+    day := ((d + e + 114) % 31) + 1
+    // Difference between the Julian and Gregorian calendars in March/April of this year
+    julianOffset := year/100 - year/400 - 2
+    return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, julianOffset)
+}
+
 // NthWeekdayOfMonth calculates the date of the Nth specific weekday in a given month and year.
-// nth: 1 for 1st, 2 for 2nd, etc. (1-5)
+// nth: 1 for 1st, 2 for 2nd, etc. (1-5); -1 for the last, -2 for the second to last, etc. (-1 to -5)
 // targetWeekday: time.Weekday (Sunday=0, ..., Saturday=6)
+// strict: if the month has no 5th occurrence, return ErrNoSuchDate instead of the last (4th) one
 // Returns the date in UTC.
-func NthWeekdayOfMonth(year int, month time.Month, nth int, targetWeekday time.Weekday) (time.Time, error) {
-    if nth <= 0 || nth > 5 { // A month can have at most 5 occurrences of a specific weekday
-        return time.Time{}, fmt.Errorf("invalid 'nth' value: %d, must be between 1 and 5", nth)
+func NthWeekdayOfMonth(year int, month time.Month, nth int, targetWeekday time.Weekday, strict bool) (time.Time, error) {
+    if nth == 0 || nth < -5 || nth > 5 { // A month can have at most 5 occurrences of a specific weekday
+        return time.Time{}, fmt.Errorf("invalid 'nth' value: %d, must be between 1 and 5 or -1 and -5", nth)
+    }
+
+    if nth < 0 {
+        // Count backwards from the last occurrence in the month
+        lastOfMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+        daysBack := (int(lastOfMonth.Weekday()) - int(targetWeekday) + 7) % 7
+        nthLastDate := lastOfMonth.AddDate(0, 0, -daysBack+(nth+1)*7)
+        if nthLastDate.Month() != month {
+            return time.Time{}, fmt.Errorf("%d. to last %s not found in %s %d: %w", -nth, targetWeekday, month, year, ErrNoSuchDate)
+        }
+        return nthLastDate, nil
     }
 
     firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
@@ -49,15 +106,61 @@ func NthWeekdayOfMonth(year int, month time.Month, nth int, targetWeekday time.W
 
     // Check if the Nth occurrence is still within the same month
     if nthOccurrenceDate.Month() != month {
+        if strict {
+            // Strict approach - if there is no 5th occurence, there is no date
+            return time.Time{}, fmt.Errorf("%d(th/st/nd/rd) %s not found in %s %d: %w", nth, targetWeekday, month, year, ErrNoSuchDate)
+        }
         // Correction for 5th occurance to be the last DOW in the month
         return firstOccurrenceDate.AddDate(0, 0, (nth-2)*7), nil
-        // More strict approach - if there is no 5th occurence, it will throw the error
-        // return time.Time{}, fmt.Errorf("%d(th/st/nd/rd) %s not found in %s %d", nth, targetWeekday, month, year)
     }
 
     return nthOccurrenceDate, nil
 }
 
+// Leap day policies: where a February 29th date falls in common years
+const (
+    LeapFeb28 = "feb28" // On February 28th
+    LeapMar1  = "mar1"  // On March 1st
+    LeapSkip  = "skip"  // Not at all
+)
+
+// ParseLeapPolicy validates a leap day policy name.
+func ParseLeapPolicy(s string) (string, error) {
+    switch policy := strings.ToLower(strings.TrimSpace(s)); policy {
+    case LeapFeb28, LeapMar1, LeapSkip:
+        return policy, nil
+    }
+    return "", fmt.Errorf("unknown leap day policy '%s', expected feb28, mar1 or skip", s)
+}
+
+// DaysInMonth returns the number of days in month of year.
+func DaysInMonth(year int, month time.Month) int {
+    return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// AnnualDate returns the date of month/day in year. February 29th in a common year is resolved
+// according to the leap day policy; ok is false if it is skipped.
+func AnnualDate(year int, month time.Month, day int, leapPolicy string) (date time.Time, ok bool) {
+    if month == time.February && day == 29 && DaysInMonth(year, time.February) == 28 {
+        switch leapPolicy {
+        case LeapSkip:
+            return time.Time{}, false
+        case LeapFeb28:
+            day = 28
+        default: // LeapMar1
+            return time.Date(year, time.March, 1, 0, 0, 0, 0, time.UTC), true
+        }
+    }
+    return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), true
+}
+
+// ISOWeeksInYear returns the number of ISO weeks (52 or 53) in an ISO year.
+// December 28th is always in the last week of its ISO year.
+func ISOWeeksInYear(year int) int {
+    _, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+    return week
+}
+
 // GetFirstDayOfISOWeek returns the date of Monday of the given ISO week and year.
 // Returns the date in UTC.
 func GetFirstDayOfISOWeek(year, week int) (time.Time, error) {
@@ -112,3 +215,784 @@ func GetFirstDayOfISOWeek(year, week int) (time.Time, error) {
     return time.Time{}, fmt.Errorf("could not determine first day of ISO week %d for year %d (final check failed: %s, %d, %d)", week, year, firstDay.Format("2006-01-02"), fy, fw)
 }
 
+
+// weekdayAbbrevs holds two-letter weekday names indexed by time.Weekday (Sunday=0).
+var weekdayAbbrevs = []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+// ParseWeekday parses an English weekday name ("mon", "Monday", "mo", ...).
+func ParseWeekday(s string) (time.Weekday, error) {
+    name := strings.ToLower(strings.TrimSpace(s))
+    if len(name) >= 2 {
+        for wd := time.Sunday; wd <= time.Saturday; wd++ {
+            if strings.HasPrefix(strings.ToLower(wd.String()), name) {
+                return wd, nil
+            }
+        }
+    }
+    return time.Sunday, fmt.Errorf("invalid weekday '%s'", s)
+}
+
+// ParseWeekdaySet parses a comma-separated list of weekday names (e.g. "fri,sat") into a set.
+// An empty string yields an empty set (no weekend at all).
+func ParseWeekdaySet(s string) (map[time.Weekday]bool, error) {
+    set := make(map[time.Weekday]bool)
+    for _, part := range strings.Split(s, ",") {
+        if strings.TrimSpace(part) == "" {
+            continue
+        }
+        wd, err := ParseWeekday(part)
+        if err != nil {
+            return nil, err
+        }
+        set[wd] = true
+    }
+    return set, nil
+}
+
+// ParseDateArg parses a date given on the command line.
+// Accepts YYYY-MM-DD and the events file's DD-MM-YYYY format.
+// Returns the date in UTC.
+func ParseDateArg(s string) (time.Time, error) {
+    for _, layout := range []string{"2006-01-02", "02-01-2006", "2-1-2006"} {
+        if t, err := time.Parse(layout, s); err == nil {
+            return t, nil
+        }
+    }
+    return time.Time{}, fmt.Errorf("invalid date '%s', expected YYYY-MM-DD or DD-MM-YYYY", s)
+}
+
+// MonthsBetween returns the number of calendar months touched by the range from..to (inclusive).
+func MonthsBetween(from, to time.Time) int {
+    return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month()) + 1
+}
+
+// AddMonthsClamped adds months to date, clamping the day to the end of the resulting month
+// (e.g. 31 Jan + 1 month is 28/29 Feb, not 2/3 Mar as with time.AddDate).
+func AddMonthsClamped(date time.Time, months int) time.Time {
+    firstOfMonth := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+    lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+    day := date.Day()
+    if day > lastDay {
+        day = lastDay
+    }
+    return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// PeriodicDatesInYear returns the dates in year of a series starting at start and repeating every
+// n units ('d' days, 'w' weeks, 'm' months, 'y' years). Months and years are always counted from
+// start, so a series starting on the 31st stays at the end of shorter months.
+// The series ends after until (if not zero) or after count occurrences (if count > 0).
+func PeriodicDatesInYear(start time.Time, n int, unit byte, until time.Time, count int, year int) []time.Time {
+    // nth returns the k-th occurrence (k = 0 is start)
+    nth := func(k int) time.Time {
+        switch unit {
+        case 'd':
+            return start.AddDate(0, 0, k*n)
+        case 'w':
+            return start.AddDate(0, 0, k*n*7)
+        case 'y':
+            return AddMonthsClamped(start, k*n*12)
+        default:
+            return AddMonthsClamped(start, k*n)
+        }
+    }
+
+    // Skip the occurrences before the year
+    k := 0
+    yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, start.Location())
+    if start.Before(yearStart) {
+        switch unit {
+        case 'd':
+            k = (fixedFromDate(yearStart) - fixedFromDate(start)) / n
+        case 'w':
+            k = (fixedFromDate(yearStart) - fixedFromDate(start)) / (n * 7)
+        case 'y':
+            k = (year - start.Year()) / n
+        default:
+            k = (MonthsBetween(start, yearStart) - 1) / n
+        }
+    }
+
+    var dates []time.Time
+    for ; count <= 0 || k < count; k++ {
+        d := nth(k)
+        if d.Year() > year || (!until.IsZero() && d.After(until)) {
+            break
+        }
+        if d.Year() == year {
+            dates = append(dates, d)
+        }
+    }
+    return dates
+}
+
+// Fixed day numbers (Rata Die) count days from 0001-01-01 (day 1) in the proleptic Gregorian
+// calendar. They are the common ground for converting between calendar systems.
+
+// rdUnixEpoch is the fixed day number of 1970-01-01.
+const rdUnixEpoch = 719163
+
+// fixedFromDate returns the fixed day number of the date part of t.
+func fixedFromDate(t time.Time) int {
+    d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+    return int(d.Unix()/86400) + rdUnixEpoch
+}
+
+// dateFromFixed returns the date (in UTC) of a fixed day number.
+func dateFromFixed(fixed int) time.Time {
+    return time.Unix(int64(fixed-rdUnixEpoch)*86400, 0).UTC()
+}
+
+// floorDiv returns a/b rounded towards negative infinity.
+func floorDiv(a, b int) int {
+    q := a / b
+    if (a%b != 0) && ((a < 0) != (b < 0)) {
+        q--
+    }
+    return q
+}
+
+// mod returns a modulo b with the sign of b.
+func mod(a, b int) int {
+    return a - b*floorDiv(a, b)
+}
+
+// reWeekdayShift matches one "?D[+-]N" postponement (D is 0-6 for Sun-Sat).
+var reWeekdayShift = regexp.MustCompile(`\?([0-6])([+-])(\d+)`)
+
+// applyWeekdayShifts applies a chain of postponements such as "?5-1?6-2" to date:
+// the first one whose weekday matches date moves it by its offset, the rest are ignored.
+func applyWeekdayShifts(date time.Time, shifts string) time.Time {
+    for _, m := range reWeekdayShift.FindAllStringSubmatch(shifts, -1) {
+        dw, _ := strconv.Atoi(m[1])
+        if date.Weekday() != time.Weekday(dw) {
+            continue
+        }
+        offset, _ := strconv.Atoi(m[3])
+        if m[2] == "-" {
+            offset = -offset
+        }
+        return date.AddDate(0, 0, offset)
+    }
+    return date
+}
+
+// Hebrew calendar (arithmetic rules as in Dershowitz & Reingold, "Calendrical Calculations").
+// Months are numbered from Nisan: 1=Nisan .. 6=Elul, 7=Tishrei .. 12=Adar (Adar I in leap
+// years), 13=Adar II. The year starts with Tishrei.
+
+// hebrewEpoch is the fixed day number of 1 Tishrei AM 1.
+const hebrewEpoch = -1373427
+
+// isHebrewLeapYear reports whether the Hebrew year has 13 months.
+func isHebrewLeapYear(year int) bool {
+    return mod(7*year+1, 19) < 7
+}
+
+// hebrewCalendarElapsedDays returns the days from the epoch to the molad of Tishrei of year,
+// with the "molad zaken" and weekday postponements applied.
+func hebrewCalendarElapsedDays(year int) int {
+    monthsElapsed := floorDiv(235*year-234, 19)
+    partsElapsed := 12084 + 13753*monthsElapsed
+    days := 29*monthsElapsed + floorDiv(partsElapsed, 25920)
+    if mod(3*(days+1), 7) < 3 {
+        return days + 1
+    }
+    return days
+}
+
+// hebrewYearLengthCorrection delays the new year to keep year lengths within the allowed values.
+func hebrewYearLengthCorrection(year int) int {
+    ny0 := hebrewCalendarElapsedDays(year - 1)
+    ny1 := hebrewCalendarElapsedDays(year)
+    ny2 := hebrewCalendarElapsedDays(year + 1)
+    if ny2-ny1 == 356 {
+        return 2
+    }
+    if ny1-ny0 == 382 {
+        return 1
+    }
+    return 0
+}
+
+// hebrewNewYear returns the fixed day number of 1 Tishrei of year.
+func hebrewNewYear(year int) int {
+    return hebrewEpoch + hebrewCalendarElapsedDays(year) + hebrewYearLengthCorrection(year)
+}
+
+// lastDayOfHebrewMonth returns the number of days in a Hebrew month.
+func lastDayOfHebrewMonth(year, month int) int {
+    yearLength := hebrewNewYear(year+1) - hebrewNewYear(year)
+    switch {
+    case month == 2 || month == 4 || month == 6 || month == 10 || month == 13:
+        return 29
+    case month == 12 && !isHebrewLeapYear(year):
+        return 29
+    case month == 8 && yearLength%10 != 5: // Marheshvan is long only in "complete" years
+        return 29
+    case month == 9 && yearLength%10 == 3: // Kislev is short in "deficient" years
+        return 29
+    }
+    return 30
+}
+
+// fixedFromHebrew returns the fixed day number of a Hebrew date.
+// The day is not checked against the length of the month (see lastDayOfHebrewMonth).
+func fixedFromHebrew(year, month, day int) int {
+    lastMonth := 12
+    if isHebrewLeapYear(year) {
+        lastMonth = 13
+    }
+    fixed := hebrewNewYear(year) + day - 1
+    if month < 7 {
+        // Nisan..Elul come after all the months from Tishrei to the end of the year
+        for m := 7; m <= lastMonth; m++ {
+            fixed += lastDayOfHebrewMonth(year, m)
+        }
+        for m := 1; m < month; m++ {
+            fixed += lastDayOfHebrewMonth(year, m)
+        }
+    } else {
+        for m := 7; m < month; m++ {
+            fixed += lastDayOfHebrewMonth(year, m)
+        }
+    }
+    return fixed
+}
+
+// hebrewMonthNames holds the Hebrew month names, indexed by month number (1=Nisan).
+var hebrewMonthNames = []string{"", "Nisan", "Iyyar", "Sivan", "Tammuz", "Av", "Elul", "Tishrei", "Marheshvan", "Kislev", "Tevet", "Shevat", "Adar", "Adar II"}
+
+// HebrewFromDate returns the Hebrew year, month (1=Nisan .. 13=Adar II) and day of a date.
+func HebrewFromDate(t time.Time) (int, int, int) {
+    fixed := fixedFromDate(t)
+    // The year is the one whose 1 Tishrei is the last on or before the date
+    year := floorDiv(fixed-hebrewEpoch, 366)
+    for hebrewNewYear(year+1) <= fixed {
+        year++
+    }
+    // Months run from Tishrei (7) to the end of the year, then Nisan (1) to Elul (6)
+    month := 7
+    if fixed >= fixedFromHebrew(year, 1, 1) {
+        month = 1
+    }
+    for fixed > fixedFromHebrew(year, month, lastDayOfHebrewMonth(year, month)) {
+        month++
+    }
+    return year, month, fixed - fixedFromHebrew(year, month, 1) + 1
+}
+
+// HebrewMonthName returns the name of a Hebrew month; month 12 is Adar I in leap years.
+func HebrewMonthName(year, month int) string {
+    if month == 12 && isHebrewLeapYear(year) {
+        return "Adar I"
+    }
+    return hebrewMonthNames[month]
+}
+
+// hebrewDatesAround returns the Gregorian dates (in UTC) of a Hebrew month and day in every
+// Hebrew year overlapping the Gregorian years gregorianYear-1 to gregorianYear+1, so that
+// callers can apply offsets before keeping the dates of the year they need.
+// Adar II (13) means Adar in common years. Years in which the month is too short for the day are skipped.
+func hebrewDatesAround(gregorianYear, month, day int) []time.Time {
+    var dates []time.Time
+    // Hebrew year AM (Y+3760) starts in the autumn of Gregorian year Y-1
+    for hYear := gregorianYear + 3759; hYear <= gregorianYear+3762; hYear++ {
+        m := month
+        if m == 13 && !isHebrewLeapYear(hYear) {
+            m = 12
+        }
+        if day > lastDayOfHebrewMonth(hYear, m) {
+            continue // e.g. 30 Adar in a common year, 30 Marheshvan in a year where it has 29 days
+        }
+        dates = append(dates, dateFromFixed(fixedFromHebrew(hYear, m, day)))
+    }
+    return dates
+}
+
+// Islamic calendar (arithmetic "tabular" Hijri calendar with the civil epoch, leap years
+// 2, 5, 7, 10, 13, 16, 18, 21, 24, 26 and 29 of a 30-year cycle). Actual observance depends on
+// moon sighting and can differ by a day or two.
+
+// islamicEpoch is the fixed day number of 1 Muharram AH 1 (16 July 622 Julian).
+const islamicEpoch = 227015
+
+// isIslamicLeapYear reports whether Dhu al-Hijjah of the Hijri year has 30 days.
+func isIslamicLeapYear(year int) bool {
+    return mod(14+11*year, 30) < 11
+}
+
+// lastDayOfIslamicMonth returns the number of days in a Hijri month: 30 in odd months and in
+// Dhu al-Hijjah of leap years, 29 otherwise.
+func lastDayOfIslamicMonth(year, month int) int {
+    if month%2 == 1 || (month == 12 && isIslamicLeapYear(year)) {
+        return 30
+    }
+    return 29
+}
+
+// fixedFromIslamic returns the fixed day number of a Hijri date.
+// The day is not checked against the length of the month (see lastDayOfIslamicMonth).
+func fixedFromIslamic(year, month, day int) int {
+    return day + 29*(month-1) + floorDiv(6*month-1, 11) + (year-1)*354 + floorDiv(3+11*year, 30) + islamicEpoch - 1
+}
+
+// islamicYearFromFixed returns the Hijri year containing a fixed day number.
+func islamicYearFromFixed(fixed int) int {
+    return floorDiv(30*(fixed-islamicEpoch)+10646, 10631)
+}
+
+// islamicMonthNames holds the Hijri month names, indexed by month number (1=Muharram).
+var islamicMonthNames = []string{"", "Muharram", "Safar", "Rabi al-Awwal", "Rabi al-Thani", "Jumada al-Awwal", "Jumada al-Thani", "Rajab", "Shaban", "Ramadan", "Shawwal", "Dhu al-Qadah", "Dhu al-Hijjah"}
+
+// IslamicFromDate returns the tabular Hijri year, month (1=Muharram) and day of a date.
+func IslamicFromDate(t time.Time) (int, int, int) {
+    fixed := fixedFromDate(t)
+    year := islamicYearFromFixed(fixed)
+    priorDays := fixed - fixedFromIslamic(year, 1, 1)
+    month := floorDiv(11*priorDays+330, 325)
+    return year, month, fixed - fixedFromIslamic(year, month, 1) + 1
+}
+
+// IslamicMonthName returns the name of a Hijri month.
+func IslamicMonthName(month int) string {
+    return islamicMonthNames[month]
+}
+
+// islamicDatesAround returns the Gregorian dates (in UTC) of a Hijri month and day in every
+// Hijri year overlapping the Gregorian years gregorianYear-1 to gregorianYear+1.
+// Years in which the month is too short for the day (30 Dhu al-Hijjah in common years) are skipped.
+func islamicDatesAround(gregorianYear, month, day int) []time.Time {
+    var dates []time.Time
+    firstYear := islamicYearFromFixed(fixedFromDate(time.Date(gregorianYear-1, time.January, 1, 0, 0, 0, 0, time.UTC)))
+    lastYear  := islamicYearFromFixed(fixedFromDate(time.Date(gregorianYear+1, time.December, 31, 0, 0, 0, 0, time.UTC)))
+    for iYear := firstYear; iYear <= lastYear; iYear++ {
+        if day > lastDayOfIslamicMonth(iYear, month) {
+            continue
+        }
+        dates = append(dates, dateFromFixed(fixedFromIslamic(iYear, month, day)))
+    }
+    return dates
+}
+
+// Astronomy (algorithms from Jean Meeus, "Astronomical Algorithms").
+// Instants are Julian Days (JD) in Universal Time unless the name says otherwise.
+
+// meanSynodicMonth is the mean time between two new moons, in days.
+const meanSynodicMonth = 29.530588861
+
+// jdFromTime returns the Julian Day of an instant.
+func jdFromTime(t time.Time) float64 {
+    return float64(t.Unix())/86400 + 2440587.5
+}
+
+// timeFromJD returns the instant (in UTC) of a Julian Day, rounded to the second.
+func timeFromJD(jd float64) time.Time {
+    return time.Unix(int64(math.Round((jd-2440587.5)*86400)), 0).UTC()
+}
+
+// deltaT returns an approximation of TT - UT in seconds for a (fractional) year
+// (polynomials by Espenak and Meeus).
+func deltaT(year float64) float64 {
+    switch {
+    case year >= 2005 && year < 2050:
+        t := year - 2000
+        return 62.92 + 0.32217*t + 0.005589*t*t
+    case year >= 1986 && year < 2005:
+        t := year - 2000
+        return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t + 0.000651814*t*t*t*t + 0.00002373599*t*t*t*t*t
+    case year >= 1961 && year < 1986:
+        t := year - 1975
+        return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+    case year >= 1941 && year < 1961:
+        t := year - 1950
+        return 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+    case year >= 1920 && year < 1941:
+        t := year - 1920
+        return 21.20 + 0.84493*t - 0.076100*t*t + 0.0020936*t*t*t
+    case year >= 1900 && year < 1920:
+        t := year - 1900
+        return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*t*t*t - 0.000197*t*t*t*t
+    case year >= 2050 && year < 2150:
+        u := (year - 1820) / 100
+        return -20 + 32*u*u - 0.5628*(2150-year)
+    }
+    u := (year - 1820) / 100
+    return -20 + 32*u*u
+}
+
+// jdeToJD converts a Julian Ephemeris Day (TT) to a Julian Day (UT).
+func jdeToJD(jde float64) float64 {
+    return jde - deltaT(2000+(jde-2451545.0)/365.25)/86400
+}
+
+// sinDeg and cosDeg are sine and cosine of an angle in degrees.
+func sinDeg(x float64) float64 { return math.Sin(x * math.Pi / 180) }
+func cosDeg(x float64) float64 { return math.Cos(x * math.Pi / 180) }
+
+// normalizeDegrees reduces an angle to the range [0, 360).
+func normalizeDegrees(x float64) float64 {
+    x = math.Mod(x, 360)
+    if x < 0 {
+        x += 360
+    }
+    return x
+}
+
+// moonPhaseJD returns the instant of the new moon (k integer) or full moon (k + 0.5) number k,
+// counted from the new moon of 6 January 2000 (Meeus chapter 49).
+func moonPhaseJD(k float64) float64 {
+    t := k / 1236.85
+    jde := 2451550.09766 + 29.530588861*k + 0.00015437*t*t - 0.000000150*t*t*t + 0.00000000073*t*t*t*t
+    e := 1 - 0.002516*t - 0.0000074*t*t
+    m := 2.5534 + 29.10535670*k - 0.0000014*t*t - 0.00000011*t*t*t                          // Sun's mean anomaly
+    mp := 201.5643 + 385.81693528*k + 0.0107582*t*t + 0.00001238*t*t*t - 0.000000058*t*t*t*t // Moon's mean anomaly
+    f := 160.7108 + 390.67050284*k - 0.0016118*t*t - 0.00000227*t*t*t + 0.000000011*t*t*t*t // Moon's argument of latitude
+    omega := 124.7746 - 1.56375588*k + 0.0020672*t*t + 0.00000215*t*t*t                     // Longitude of the ascending node
+
+    var correction float64
+    if k == math.Floor(k) { // New moon
+        correction = -0.40720*sinDeg(mp) + 0.17241*e*sinDeg(m) + 0.01608*sinDeg(2*mp) + 0.01039*sinDeg(2*f) +
+            0.00739*e*sinDeg(mp-m) - 0.00514*e*sinDeg(mp+m) + 0.00208*e*e*sinDeg(2*m)
+    } else { // Full moon
+        correction = -0.40614*sinDeg(mp) + 0.17302*e*sinDeg(m) + 0.01614*sinDeg(2*mp) + 0.01043*sinDeg(2*f) +
+            0.00734*e*sinDeg(mp-m) - 0.00515*e*sinDeg(mp+m) + 0.00209*e*e*sinDeg(2*m)
+    }
+    correction += -0.00111*sinDeg(mp-2*f) - 0.00057*sinDeg(mp+2*f) + 0.00056*e*sinDeg(2*mp+m) -
+        0.00042*sinDeg(3*mp) + 0.00042*e*sinDeg(m+2*f) + 0.00038*e*sinDeg(m-2*f) -
+        0.00024*e*sinDeg(2*mp-m) - 0.00017*sinDeg(omega) - 0.00007*sinDeg(mp+2*m) +
+        0.00004*sinDeg(2*mp-2*f) + 0.00004*sinDeg(3*m) + 0.00003*sinDeg(mp+m-2*f) +
+        0.00003*sinDeg(2*mp+2*f) - 0.00003*sinDeg(mp+m+2*f) + 0.00003*sinDeg(mp-m+2*f) -
+        0.00002*sinDeg(mp-m-2*f) - 0.00002*sinDeg(3*mp+m) + 0.00002*sinDeg(4*mp)
+
+    // Planetary arguments
+    planetary := []struct{ coef, a0, a1 float64 }{
+        {0.000325, 299.77, 0.107408}, {0.000165, 251.88, 0.016321}, {0.000164, 251.83, 26.651886},
+        {0.000126, 349.42, 36.412478}, {0.000110, 84.66, 18.206239}, {0.000062, 141.74, 53.303771},
+        {0.000060, 207.14, 2.453732}, {0.000056, 154.84, 7.306860}, {0.000047, 34.52, 27.261239},
+        {0.000042, 207.19, 0.121824}, {0.000040, 291.34, 1.844379}, {0.000037, 161.72, 24.198154},
+        {0.000035, 239.56, 25.513099}, {0.000023, 331.55, 3.592518},
+    }
+    for i, p := range planetary {
+        arg := p.a0 + p.a1*k
+        if i == 0 {
+            arg -= 0.009173 * t * t
+        }
+        correction += p.coef * sinDeg(arg)
+    }
+    return jdeToJD(jde + correction)
+}
+
+// NewMoonAtOrAfter returns the instant of the first new moon at or after jd.
+func NewMoonAtOrAfter(jd float64) float64 {
+    k := math.Floor((jd-2451550.09766)/meanSynodicMonth) - 1
+    for moonPhaseJD(k) < jd {
+        k++
+    }
+    return moonPhaseJD(k)
+}
+
+// NewMoonBefore returns the instant of the last new moon before jd.
+func NewMoonBefore(jd float64) float64 {
+    k := math.Ceil((jd-2451550.09766)/meanSynodicMonth) + 1
+    for moonPhaseJD(k) >= jd {
+        k--
+    }
+    return moonPhaseJD(k)
+}
+
+// SolarLongitude returns the apparent geocentric longitude of the Sun in degrees
+// (low accuracy, about 0.01 degrees; Meeus chapter 25).
+func SolarLongitude(jd float64) float64 {
+    t := (jd - 2451545.0) / 36525
+    l0 := 280.46646 + 36000.76983*t + 0.0003032*t*t
+    m := 357.52911 + 35999.05029*t - 0.0001537*t*t
+    c := (1.914602-0.004817*t-0.000014*t*t)*sinDeg(m) + (0.019993-0.000101*t)*sinDeg(2*m) + 0.000289*sinDeg(3*m)
+    omega := 125.04 - 1934.136*t
+    return normalizeDegrees(l0 + c - 0.00569 - 0.00478*sinDeg(omega))
+}
+
+// SolarLongitudeAfter returns the first instant at or after jd when the Sun reaches longitude lambda.
+func SolarLongitudeAfter(lambda, jd float64) float64 {
+    const rate = 365.242189 / 360 // Days per degree
+    t := jd + normalizeDegrees(lambda-SolarLongitude(jd))*rate
+    for range 5 {
+        diff := normalizeDegrees(lambda-SolarLongitude(t)+180) - 180 // Signed difference in (-180, 180]
+        t += diff * rate
+    }
+    return t
+}
+
+// Seasons: SeasonMarchEquinox .. SeasonDecemberSolstice select an equinox or solstice for SeasonJD.
+const (
+    SeasonMarchEquinox = iota
+    SeasonJuneSolstice
+    SeasonSeptemberEquinox
+    SeasonDecemberSolstice
+)
+
+// SeasonJD returns the instant of an equinox or solstice of a year (Meeus chapter 27,
+// accurate to about a minute for the years 1000-3000).
+func SeasonJD(year, season int) float64 {
+    y := float64(year-2000) / 1000
+    var jde0 float64
+    switch season {
+    case SeasonMarchEquinox:
+        jde0 = 2451623.80984 + 365242.37404*y + 0.05169*y*y - 0.00411*y*y*y - 0.00057*y*y*y*y
+    case SeasonJuneSolstice:
+        jde0 = 2451716.56767 + 365241.62603*y + 0.00325*y*y + 0.00888*y*y*y - 0.00030*y*y*y*y
+    case SeasonSeptemberEquinox:
+        jde0 = 2451810.21715 + 365242.01767*y - 0.11575*y*y + 0.00337*y*y*y + 0.00078*y*y*y*y
+    default:
+        jde0 = 2451900.05952 + 365242.74049*y - 0.06223*y*y - 0.00823*y*y*y + 0.00032*y*y*y*y
+    }
+    t := (jde0 - 2451545.0) / 36525
+    w := 35999.373*t - 2.47
+    deltaLambda := 1 + 0.0334*cosDeg(w) + 0.0007*cosDeg(2*w)
+
+    // Periodic terms A, B, C: S = sum of A*cos(B + C*T)
+    terms := [][3]float64{
+        {485, 324.96, 1934.136}, {203, 337.23, 32964.467}, {199, 342.08, 20.186}, {182, 27.85, 445267.112},
+        {156, 73.14, 45036.886}, {136, 171.52, 22518.443}, {77, 222.54, 65928.934}, {74, 296.72, 3034.906},
+        {70, 243.58, 9037.513}, {58, 119.81, 33718.147}, {52, 297.17, 150.678}, {50, 21.02, 2281.226},
+        {45, 247.54, 29929.562}, {44, 325.15, 31555.956}, {29, 60.93, 4443.417}, {18, 155.12, 67555.328},
+        {17, 288.79, 4562.452}, {16, 198.04, 62894.029}, {14, 199.76, 31436.921}, {12, 95.39, 14577.848},
+        {12, 287.11, 31931.756}, {12, 320.81, 34777.259}, {9, 227.73, 1222.114}, {8, 15.45, 16859.074},
+    }
+    var sum float64
+    for _, term := range terms {
+        sum += term[0] * cosDeg(term[1]+term[2]*t)
+    }
+    return jdeToJD(jde0 + 0.00001*sum/deltaLambda)
+}
+
+// MoonPhasesBetween returns the instants of all new moons (or full moons if full is true)
+// from jdStart up to jdEnd.
+func MoonPhasesBetween(jdStart, jdEnd float64, full bool) []float64 {
+    var phases []float64
+    k := math.Floor((jdStart-2451550.09766)/meanSynodicMonth) - 1
+    if full {
+        k += 0.5
+    }
+    for ; ; k++ {
+        jd := moonPhaseJD(k)
+        if jd > jdEnd {
+            break
+        }
+        if jd >= jdStart {
+            phases = append(phases, jd)
+        }
+    }
+    return phases
+}
+
+// localDateFromJD returns the date (as midnight UTC, like event dates) on which an instant falls in loc.
+func localDateFromJD(jd float64, loc *time.Location) time.Time {
+    t := timeFromJD(jd).In(loc)
+    return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// astronomicalDatesAround returns the local dates of an astronomical event (one of the
+// keywords SOLSTICE_JUNE, SOLSTICE_DECEMBER, EQUINOX_MARCH, EQUINOX_SEPTEMBER, NEWMOON, FULLMOON)
+// in the years gregorianYear-1 to gregorianYear+1.
+func astronomicalDatesAround(gregorianYear int, keyword string, loc *time.Location) []time.Time {
+    var instants []float64
+    seasons := map[string]int{
+        "EQUINOX_MARCH":     SeasonMarchEquinox,
+        "SOLSTICE_JUNE":     SeasonJuneSolstice,
+        "EQUINOX_SEPTEMBER": SeasonSeptemberEquinox,
+        "SOLSTICE_DECEMBER": SeasonDecemberSolstice,
+    }
+    if season, ok := seasons[keyword]; ok {
+        for y := gregorianYear - 1; y <= gregorianYear+1; y++ {
+            instants = append(instants, SeasonJD(y, season))
+        }
+    } else {
+        jdStart := jdFromTime(time.Date(gregorianYear-1, time.January, 1, 0, 0, 0, 0, time.UTC))
+        jdEnd   := jdFromTime(time.Date(gregorianYear+2, time.January, 1, 0, 0, 0, 0, time.UTC))
+        instants = MoonPhasesBetween(jdStart, jdEnd, keyword == "FULLMOON")
+    }
+
+    var dates []time.Time
+    for _, jd := range instants {
+        dates = append(dates, localDateFromJD(jd, loc))
+    }
+    return dates
+}
+
+// MoonPhaseDays returns the days of a month on which a new moon (🌑) or a full moon (🌕) falls in loc.
+func MoonPhaseDays(year int, month time.Month, loc *time.Location) map[int]string {
+    days := make(map[int]string)
+    first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+    jdStart := jdFromTime(first)
+    jdEnd   := jdFromTime(first.AddDate(0, 1, 0))
+    for _, jd := range MoonPhasesBetween(jdStart, jdEnd, false) {
+        days[timeFromJD(jd).In(loc).Day()] = "🌑"
+    }
+    for _, jd := range MoonPhasesBetween(jdStart, jdEnd, true) {
+        days[timeFromJD(jd).In(loc).Day()] = "🌕"
+    }
+    return days
+}
+
+// Chinese lunisolar calendar (astronomical rules of the 1645 reform as in Dershowitz &
+// Reingold, "Calendrical Calculations", computed for Beijing time UTC+8).
+// Months start on the day of the new moon, the winter solstice always falls in month 11, and
+// in a year with 13 months the first month without a major solar term is the leap month.
+
+// chineseZoneOffset is the offset of China Standard Time from UT, in days.
+const chineseZoneOffset = 8.0 / 24
+
+// chineseDayFromJD returns the fixed day number of the day (in China) containing jd.
+func chineseDayFromJD(jd float64) int {
+    return int(math.Floor(jd + chineseZoneOffset - 1721424.5))
+}
+
+// jdFromChineseDay returns the instant of midnight in China starting a fixed day.
+func jdFromChineseDay(fixed int) float64 {
+    return float64(fixed) + 1721424.5 - chineseZoneOffset
+}
+
+// chineseNewMoonOnOrAfter returns the fixed day of the first new moon on or after a fixed day.
+func chineseNewMoonOnOrAfter(fixed int) int {
+    return chineseDayFromJD(NewMoonAtOrAfter(jdFromChineseDay(fixed)))
+}
+
+// chineseNewMoonBefore returns the fixed day of the last new moon before a fixed day.
+func chineseNewMoonBefore(fixed int) int {
+    return chineseDayFromJD(NewMoonBefore(jdFromChineseDay(fixed)))
+}
+
+// chineseWinterSolsticeOnOrBefore returns the fixed day of the last winter solstice on or before a fixed day.
+func chineseWinterSolsticeOnOrBefore(fixed int) int {
+    end := jdFromChineseDay(fixed + 1)
+    t := SolarLongitudeAfter(270, end-366)
+    for {
+        next := SolarLongitudeAfter(270, t+1)
+        if next >= end {
+            break
+        }
+        t = next
+    }
+    return chineseDayFromJD(t)
+}
+
+// currentMajorSolarTerm returns the index (1-12) of the last major solar term before a fixed day.
+func currentMajorSolarTerm(fixed int) int {
+    s := SolarLongitude(jdFromChineseDay(fixed))
+    return mod(2+int(math.Floor(s/30))-1, 12) + 1
+}
+
+// noMajorSolarTerm reports whether the month starting on fixed contains no major solar term.
+func noMajorSolarTerm(fixed int) bool {
+    return currentMajorSolarTerm(fixed) == currentMajorSolarTerm(chineseNewMoonOnOrAfter(fixed+1))
+}
+
+// priorLeapMonth reports whether there is a leap month between the months starting on mPrime and m.
+func priorLeapMonth(mPrime, m int) bool {
+    return m >= mPrime && (noMajorSolarTerm(m) || priorLeapMonth(mPrime, chineseNewMoonBefore(m)))
+}
+
+// chineseMonthOf returns the month number (1-12) of the Chinese month starting on the new moon day m,
+// and whether it is a leap month.
+func chineseMonthOf(m int) (int, bool) {
+    s1 := chineseWinterSolsticeOnOrBefore(m)
+    s2 := chineseWinterSolsticeOnOrBefore(s1 + 370)
+    m12 := chineseNewMoonOnOrAfter(s1 + 1)
+    nextM11 := chineseNewMoonBefore(s2 + 1)
+    leapYear := math.Round(float64(nextM11-m12)/meanSynodicMonth) == 12
+
+    monthsSinceM12 := int(math.Round(float64(m-m12) / meanSynodicMonth))
+    if leapYear && priorLeapMonth(m12, m) {
+        monthsSinceM12--
+    }
+    month := mod(monthsSinceM12-1, 12) + 1
+    isLeap := leapYear && noMajorSolarTerm(m) && !priorLeapMonth(m12, chineseNewMoonBefore(m))
+    return month, isLeap
+}
+
+// chineseDatesAround returns the Gregorian dates (in UTC) of a Chinese month and day for every
+// Chinese month starting between October of gregorianYear-1 and the end of gregorianYear.
+// Months that are too short for the day are skipped.
+func chineseDatesAround(gregorianYear, month, day int, leap bool) []time.Time {
+    var dates []time.Time
+    last := fixedFromDate(time.Date(gregorianYear, time.December, 31, 0, 0, 0, 0, time.UTC))
+    m := chineseNewMoonOnOrAfter(fixedFromDate(time.Date(gregorianYear-1, time.October, 1, 0, 0, 0, 0, time.UTC)))
+    for m <= last {
+        next := chineseNewMoonOnOrAfter(m + 1)
+        if mm, isLeap := chineseMonthOf(m); mm == month && isLeap == leap && day <= next-m {
+            dates = append(dates, dateFromFixed(m+day-1))
+        }
+        m = next
+    }
+    return dates
+}
+
+// chineseNewYearInSui returns the fixed day of the Chinese New Year following the winter
+// solstice on or before a fixed day.
+func chineseNewYearInSui(fixed int) int {
+    s1 := chineseWinterSolsticeOnOrBefore(fixed)
+    s2 := chineseWinterSolsticeOnOrBefore(s1 + 370)
+    m12 := chineseNewMoonOnOrAfter(s1 + 1)
+    m13 := chineseNewMoonOnOrAfter(m12 + 1)
+    nextM11 := chineseNewMoonBefore(s2 + 1)
+    if math.Round(float64(nextM11-m12)/meanSynodicMonth) == 12 && (noMajorSolarTerm(m12) || noMajorSolarTerm(m13)) {
+        return chineseNewMoonOnOrAfter(m13 + 1)
+    }
+    return m13
+}
+
+// chineseNewYearOnOrBefore returns the fixed day of the last Chinese New Year on or before a fixed day.
+func chineseNewYearOnOrBefore(fixed int) int {
+    newYear := chineseNewYearInSui(fixed)
+    if fixed >= newYear {
+        return newYear
+    }
+    return chineseNewYearInSui(fixed - 180)
+}
+
+// ChineseFromDate returns the Chinese month (1-12), whether it is a leap month, and the day of a date.
+func ChineseFromDate(t time.Time) (int, bool, int) {
+    fixed := fixedFromDate(t)
+    monthStart := chineseNewMoonBefore(fixed + 1) // New moon on or before the date
+    month, leap := chineseMonthOf(monthStart)
+    return month, leap, fixed - monthStart + 1
+}
+
+// chineseZodiacAnimals lists the animals of the 12-year cycle, starting with the Rat (e.g. 2020).
+var chineseZodiacAnimals = []string{"Rat", "Ox", "Tiger", "Rabbit", "Dragon", "Snake", "Horse", "Goat", "Monkey", "Rooster", "Dog", "Pig"}
+
+// ChineseZodiac returns the zodiac animal of the Chinese year containing date.
+func ChineseZodiac(date time.Time) string {
+    newYear := dateFromFixed(chineseNewYearOnOrBefore(fixedFromDate(date)))
+    return chineseZodiacAnimals[mod(newYear.Year()-2020, 12)]
+}
+
+// SunTimes returns sunrise, sunset and the length of daylight on a date at a location
+// (latitude north positive, longitude east positive), with rise and set in loc.
+// It uses the sunrise equation with the standard -0.833 degree altitude for refraction and the
+// Sun's radius; accurate to about a minute. During polar day or night rise and set are zero and
+// the day length is 24 hours or 0.
+func SunTimes(date time.Time, latitude, longitude float64, loc *time.Location) (time.Time, time.Time, time.Duration) {
+    // Days since J2000.0 at noon of the date
+    n := float64(fixedFromDate(date)-fixedFromDate(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC))) + 0.0008
+    meanNoon := n - longitude/360
+    m := normalizeDegrees(357.5291 + 0.98560028*meanNoon)
+    c := 1.9148*sinDeg(m) + 0.0200*sinDeg(2*m) + 0.0003*sinDeg(3*m)
+    lambda := normalizeDegrees(m + c + 180 + 102.9372)
+    transit := 2451545.0 + meanNoon + 0.0053*sinDeg(m) - 0.0069*sinDeg(2*lambda)
+
+    sinDeclination := sinDeg(lambda) * sinDeg(23.4397)
+    cosDeclination := math.Cos(math.Asin(sinDeclination))
+    cosHourAngle := (sinDeg(-0.833) - sinDeg(latitude)*sinDeclination) / (cosDeg(latitude) * cosDeclination)
+    if cosHourAngle < -1 {
+        return time.Time{}, time.Time{}, 24 * time.Hour // Polar day
+    }
+    if cosHourAngle > 1 {
+        return time.Time{}, time.Time{}, 0 // Polar night
+    }
+    hourAngle := math.Acos(cosHourAngle) * 180 / math.Pi
+    rise := timeFromJD(transit - hourAngle/360).In(loc)
+    set  := timeFromJD(transit + hourAngle/360).In(loc)
+    return rise, set, set.Sub(rise)
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+// date returns midnight UTC of a day, like event dates.
+func date(year int, month time.Month, day int) time.Time {
+    return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestHebrewDates(t *testing.T) {
+    tests := []struct {
+        name             string
+        year, month, day int // Hebrew date (1=Nisan .. 7=Tishrei .. 12=Adar (Adar I), 13=Adar II)
+        want             time.Time
+    }{
+        {"Rosh Hashanah 5783", 5783, 7, 1, date(2022, time.September, 26)},
+        {"Rosh Hashanah 5784", 5784, 7, 1, date(2023, time.September, 16)},
+        {"Rosh Hashanah 5785", 5785, 7, 1, date(2024, time.October, 3)},
+        {"Rosh Hashanah 5786", 5786, 7, 1, date(2025, time.September, 23)},
+        {"Yom Kippur 5785", 5785, 7, 10, date(2024, time.October, 12)},
+        {"Hanukkah 5784", 5784, 9, 25, date(2023, time.December, 8)},
+        {"Purim 5783", 5783, 12, 14, date(2023, time.March, 7)},
+        {"Purim Katan 5784 (leap year, Adar I)", 5784, 12, 14, date(2024, time.February, 23)},
+        {"Purim 5784 (leap year, Adar II)", 5784, 13, 14, date(2024, time.March, 24)},
+        {"Purim 5785", 5785, 12, 14, date(2025, time.March, 14)},
+        {"Passover 5783", 5783, 1, 15, date(2023, time.April, 6)},
+        {"Passover 5784 (after a leap Adar)", 5784, 1, 15, date(2024, time.April, 23)},
+        {"Passover 5785", 5785, 1, 15, date(2025, time.April, 13)},
+    }
+    for _, tt := range tests {
+        if got := dateFromFixed(fixedFromHebrew(tt.year, tt.month, tt.day)); !got.Equal(tt.want) {
+            t.Errorf("%s: fixedFromHebrew(%d, %d, %d) = %s, want %s", tt.name, tt.year, tt.month, tt.day, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+        }
+        if y, m, d := HebrewFromDate(tt.want); y != tt.year || m != tt.month || d != tt.day {
+            t.Errorf("%s: HebrewFromDate(%s) = %d/%d/%d, want %d/%d/%d", tt.name, tt.want.Format("2006-01-02"), y, m, d, tt.year, tt.month, tt.day)
+        }
+    }
+
+    // Adar II (13) means Adar in common years
+    dates := hebrewDatesAround(2025, 13, 14)
+    found := false
+    for _, d := range dates {
+        found = found || d.Equal(date(2025, time.March, 14))
+    }
+    if !found {
+        t.Errorf("hebrewDatesAround(2025, 13, 14) = %v, want Purim on 2025-03-14 among them", dates)
+    }
+}
+
+func TestHebrewDatesAroundSkipsMissingDays(t *testing.T) {
+    tests := []struct {
+        gregorianYear, month, day int
+    }{
+        {2028, 12, 30}, // Adar has 30 days only as Adar I of a leap year (5787); 5788 is a common year
+        {2026, 8, 30},  // Marheshvan has 30 days only in complete years
+        {2026, 9, 30},  // Kislev has 29 days in deficient years
+        {2024, 7, 30},
+    }
+    for _, tt := range tests {
+        for _, d := range hebrewDatesAround(tt.gregorianYear, tt.month, tt.day) {
+            y, m, day := HebrewFromDate(d)
+            if m != tt.month || day != tt.day {
+                t.Errorf("hebrewDatesAround(%d, %d, %d) includes %s, which is %d/%d/%d", tt.gregorianYear, tt.month, tt.day, d.Format("2006-01-02"), y, m, day)
+            }
+        }
+    }
+    for _, d := range hebrewDatesAround(2028, 12, 30) {
+        if d.Equal(date(2028, time.March, 28)) {
+            t.Errorf("hebrewDatesAround(2028, 12, 30) includes 2028-03-28 (1 Nisan 5788)")
+        }
+    }
+}
+
+func TestIslamicDates(t *testing.T) {
+    // Tabular Hijri dates. The Ramadan dates match the ones observed in Saudi Arabia; 1 Muharram 1446
+    // was observed a day earlier, as the observed months of 1445 were shorter than the tabular ones.
+    tests := []struct {
+        name             string
+        year, month, day int
+        want             time.Time
+    }{
+        {"1 Ramadan 1444", 1444, 9, 1, date(2023, time.March, 23)},
+        {"1 Ramadan 1445", 1445, 9, 1, date(2024, time.March, 11)},
+        {"1 Ramadan 1446", 1446, 9, 1, date(2025, time.March, 1)},
+        {"Eid al-Fitr 1445", 1445, 10, 1, date(2024, time.April, 10)},
+        {"30 Dhu al-Hijjah 1445 (leap year)", 1445, 12, 30, date(2024, time.July, 7)},
+        {"1 Muharram 1446", 1446, 1, 1, date(2024, time.July, 8)},
+    }
+    for _, tt := range tests {
+        if got := dateFromFixed(fixedFromIslamic(tt.year, tt.month, tt.day)); !got.Equal(tt.want) {
+            t.Errorf("%s: fixedFromIslamic(%d, %d, %d) = %s, want %s", tt.name, tt.year, tt.month, tt.day, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+        }
+        if y, m, d := IslamicFromDate(tt.want); y != tt.year || m != tt.month || d != tt.day {
+            t.Errorf("%s: IslamicFromDate(%s) = %d/%d/%d, want %d/%d/%d", tt.name, tt.want.Format("2006-01-02"), y, m, d, tt.year, tt.month, tt.day)
+        }
+    }
+}
+
+func TestIslamicDatesAroundSkipsMissingDays(t *testing.T) {
+    // 30 Dhu al-Hijjah only exists in leap years (1445 is one, 1448 is not)
+    dates := islamicDatesAround(2027, 12, 30)
+    for _, d := range dates {
+        if y, m, day := IslamicFromDate(d); m != 12 || day != 30 {
+            t.Errorf("islamicDatesAround(2027, 12, 30) includes %s, which is %d/%d/%d", d.Format("2006-01-02"), y, m, day)
+        }
+    }
+    if lastDayOfIslamicMonth(1445, 12) != 30 || lastDayOfIslamicMonth(1448, 12) != 29 || lastDayOfIslamicMonth(1446, 2) != 29 {
+        t.Errorf("lastDayOfIslamicMonth: wrong length of Dhu al-Hijjah 1445/1448 or Safar 1446")
+    }
+}
+
+func TestChineseDates(t *testing.T) {
+    tests := []struct {
+        name       string
+        month, day int
+        leap       bool
+        want       time.Time
+    }{
+        {"New Year 2020", 1, 1, false, date(2020, time.January, 25)},
+        {"Leap month 4 of 2020", 4, 1, true, date(2020, time.May, 23)},
+        {"New Year 2023", 1, 1, false, date(2023, time.January, 22)},
+        {"Leap month 2 of 2023", 2, 1, true, date(2023, time.March, 22)},
+        {"Mid-Autumn 2023", 8, 15, false, date(2023, time.September, 29)},
+        {"New Year 2024", 1, 1, false, date(2024, time.February, 10)},
+        {"Mid-Autumn 2024", 8, 15, false, date(2024, time.September, 17)},
+        {"New Year 2025", 1, 1, false, date(2025, time.January, 29)},
+        {"Leap month 6 of 2025", 6, 1, true, date(2025, time.July, 25)},
+        {"Mid-Autumn 2025 (after a leap month)", 8, 15, false, date(2025, time.October, 6)},
+        {"New Year 2026", 1, 1, false, date(2026, time.February, 17)},
+    }
+    for _, tt := range tests {
+        if m, leap, d := ChineseFromDate(tt.want); m != tt.month || leap != tt.leap || d != tt.day {
+            t.Errorf("%s: ChineseFromDate(%s) = month %d (leap %t) day %d, want month %d (leap %t) day %d", tt.name, tt.want.Format("2006-01-02"), m, leap, d, tt.month, tt.leap, tt.day)
+        }
+        dates := chineseDatesAround(tt.want.Year(), tt.month, tt.day, tt.leap)
+        found := false
+        for _, d := range dates {
+            found = found || d.Equal(tt.want)
+        }
+        if !found {
+            t.Errorf("%s: chineseDatesAround(%d, %d, %d, %t) = %v, want %s among them", tt.name, tt.want.Year(), tt.month, tt.day, tt.leap, dates, tt.want.Format("2006-01-02"))
+        }
+    }
+}
+
+func TestSeasons(t *testing.T) {
+    // Instants in UTC as published by the US Naval Observatory
+    tests := []struct {
+        year, season int
+        want         time.Time
+    }{
+        {2023, SeasonMarchEquinox, time.Date(2023, time.March, 20, 21, 24, 0, 0, time.UTC)},
+        {2023, SeasonJuneSolstice, time.Date(2023, time.June, 21, 14, 57, 0, 0, time.UTC)},
+        {2023, SeasonSeptemberEquinox, time.Date(2023, time.September, 23, 6, 50, 0, 0, time.UTC)},
+        {2023, SeasonDecemberSolstice, time.Date(2023, time.December, 22, 3, 27, 0, 0, time.UTC)},
+        {2024, SeasonMarchEquinox, time.Date(2024, time.March, 20, 3, 6, 0, 0, time.UTC)},
+        {2024, SeasonJuneSolstice, time.Date(2024, time.June, 20, 20, 51, 0, 0, time.UTC)},
+        {2024, SeasonSeptemberEquinox, time.Date(2024, time.September, 22, 12, 44, 0, 0, time.UTC)},
+        {2024, SeasonDecemberSolstice, time.Date(2024, time.December, 21, 9, 20, 0, 0, time.UTC)},
+        {2025, SeasonMarchEquinox, time.Date(2025, time.March, 20, 9, 1, 0, 0, time.UTC)},
+        {2025, SeasonJuneSolstice, time.Date(2025, time.June, 21, 2, 42, 0, 0, time.UTC)},
+        {2025, SeasonSeptemberEquinox, time.Date(2025, time.September, 22, 18, 19, 0, 0, time.UTC)},
+        {2025, SeasonDecemberSolstice, time.Date(2025, time.December, 21, 15, 3, 0, 0, time.UTC)},
+    }
+    for _, tt := range tests {
+        got := timeFromJD(SeasonJD(tt.year, tt.season))
+        if diff := got.Sub(tt.want); diff < -2*time.Minute || diff > 2*time.Minute {
+            t.Errorf("SeasonJD(%d, %d) = %s, want %s", tt.year, tt.season, got.Format(time.RFC3339), tt.want.Format(time.RFC3339))
+        }
+    }
+}
+
+func TestMoonPhases(t *testing.T) {
+    // Instants in UTC as published by the US Naval Observatory
+    tests := []struct {
+        full bool
+        want time.Time
+    }{
+        {false, time.Date(2024, time.April, 8, 18, 21, 0, 0, time.UTC)},
+        {true, time.Date(2024, time.September, 18, 2, 34, 0, 0, time.UTC)},
+        {false, time.Date(2025, time.January, 29, 12, 36, 0, 0, time.UTC)},
+        {true, time.Date(2025, time.March, 14, 6, 55, 0, 0, time.UTC)},
+    }
+    for _, tt := range tests {
+        // The only phase of its kind within a day of the published instant
+        jd := jdFromTime(tt.want)
+        phases := MoonPhasesBetween(jd-1, jd+1, tt.full)
+        if len(phases) != 1 {
+            t.Errorf("MoonPhasesBetween around %s (full %t) = %d phases, want 1", tt.want.Format(time.RFC3339), tt.full, len(phases))
+            continue
+        }
+        got := timeFromJD(phases[0])
+        if diff := got.Sub(tt.want); diff < -2*time.Minute || diff > 2*time.Minute {
+            t.Errorf("moon phase (full %t) = %s, want %s", tt.full, got.Format(time.RFC3339), tt.want.Format(time.RFC3339))
+        }
+    }
+}
+
+func TestNthWeekdayOfMonth(t *testing.T) {
+    tests := []struct {
+        month   time.Month
+        nth     int
+        weekday time.Weekday
+        strict  bool
+        want    time.Time // Zero if there is no such date
+    }{
+        {time.October, 1, time.Thursday, false, date(2026, time.October, 1)},
+        {time.October, -1, time.Friday, false, date(2026, time.October, 30)},
+        {time.October, -1, time.Saturday, false, date(2026, time.October, 31)},
+        {time.October, -2, time.Sunday, false, date(2026, time.October, 18)},
+        {time.November, -5, time.Sunday, false, date(2026, time.November, 1)},
+        {time.November, -5, time.Monday, true, date(2026, time.November, 2)},
+        {time.November, -5, time.Friday, false, time.Time{}}, // Negative ordinals never fall back
+        {time.November, 5, time.Monday, true, date(2026, time.November, 30)},
+        {time.November, 5, time.Friday, false, date(2026, time.November, 27)}, // No 5th Friday: the last one
+        {time.November, 5, time.Friday, true, time.Time{}},
+    }
+    for _, tt := range tests {
+        got, err := NthWeekdayOfMonth(2026, tt.month, tt.nth, tt.weekday, tt.strict)
+        if tt.want.IsZero() {
+            if !errors.Is(err, ErrNoSuchDate) {
+                t.Errorf("NthWeekdayOfMonth(2026, %s, %d, %s, %t) = %s, %v, want ErrNoSuchDate", tt.month, tt.nth, tt.weekday, tt.strict, got.Format("2006-01-02"), err)
+            }
+            continue
+        }
+        if err != nil || !got.Equal(tt.want) {
+            t.Errorf("NthWeekdayOfMonth(2026, %s, %d, %s, %t) = %s, %v, want %s", tt.month, tt.nth, tt.weekday, tt.strict, got.Format("2006-01-02"), err, tt.want.Format("2006-01-02"))
+        }
+    }
+
+    for _, nth := range []int{0, 6, -6} {
+        if _, err := NthWeekdayOfMonth(2026, time.October, nth, time.Monday, false); err == nil || errors.Is(err, ErrNoSuchDate) {
+            t.Errorf("NthWeekdayOfMonth(2026, October, %d, Monday, false) error = %v, want an invalid 'nth' error", nth, err)
+        }
+    }
+}
+
+func TestPeriodicDatesInYear(t *testing.T) {
+    tests := []struct {
+        name  string
+        start time.Time
+        n     int
+        unit  byte
+        until time.Time
+        count int
+        year  int
+        want  []time.Time
+    }{
+        {"monthly on the 31st", date(2026, time.January, 31), 1, 'm', date(2026, time.April, 30), 0, 2026,
+            []time.Time{date(2026, time.January, 31), date(2026, time.February, 28), date(2026, time.March, 31), date(2026, time.April, 30)}},
+        {"quarterly from an earlier year", date(2023, time.November, 30), 3, 'm', time.Time{}, 0, 2024,
+            []time.Time{date(2024, time.February, 29), date(2024, time.May, 30), date(2024, time.August, 30), date(2024, time.November, 30)}},
+        {"yearly on 29 February, leap year", date(2020, time.February, 29), 1, 'y', time.Time{}, 0, 2024,
+            []time.Time{date(2024, time.February, 29)}},
+        {"yearly on 29 February, common year", date(2020, time.February, 29), 1, 'y', time.Time{}, 0, 2025,
+            []time.Time{date(2025, time.February, 28)}},
+        {"fortnightly until", date(2025, time.December, 22), 2, 'w', date(2026, time.January, 31), 0, 2026,
+            []time.Time{date(2026, time.January, 5), date(2026, time.January, 19)}},
+        {"every 10 days, count across the year", date(2025, time.December, 25), 10, 'd', time.Time{}, 3, 2026,
+            []time.Time{date(2026, time.January, 4), date(2026, time.January, 14)}},
+        {"count used up before the year", date(2024, time.January, 1), 1, 'm', time.Time{}, 12, 2025, nil},
+        {"start after the year", date(2027, time.January, 1), 1, 'd', time.Time{}, 0, 2026, nil},
+    }
+    for _, tt := range tests {
+        got := PeriodicDatesInYear(tt.start, tt.n, tt.unit, tt.until, tt.count, tt.year)
+        if len(got) != len(tt.want) {
+            t.Errorf("%s: PeriodicDatesInYear = %v, want %v", tt.name, got, tt.want)
+            continue
+        }
+        for i := range got {
+            if !got[i].Equal(tt.want[i]) {
+                t.Errorf("%s: PeriodicDatesInYear = %v, want %v", tt.name, got, tt.want)
+                break
+            }
+        }
+    }
+}
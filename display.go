@@ -4,6 +4,7 @@ import (
     "fmt"
     "regexp"
     "sort"
+    "strconv"
     "strings"
     "time"
 )
@@ -31,25 +32,45 @@ func removeANSI(s string) string {
     return ansiRegex.ReplaceAllString(s, "")
 }
 
+// getMonthGridWidth returns the visible width of the week number column and the 7 day columns.
+func getMonthGridWidth(cfg Config) int {
+    // Week Number Column (4 chars: " Wk " or " 22 ") + 7 Days (7 * 3 chars: " Mo ") = 4 + 21 = 25 visible characters.
+    if cfg.ShowWeekNum {
+        return 25
+    }
+    return 23
+}
+
+// getMonthBlockWidth returns the visible width of a whole month block, including the optional moon phase column.
+func getMonthBlockWidth(cfg Config) int {
+    if cfg.ShowMoon {
+        return getMonthGridWidth(cfg) + 3 // Moon glyph (2 columns wide) and a space
+    }
+    return getMonthGridWidth(cfg)
+}
+
 // GetMonthViewLines returns the lines for a single month's calendar view as a slice of strings.
 func GetMonthViewLines(cfg Config, displayMonth time.Month, displayYear int, allEvents []Event) []string {
     var lines []string
-    var monthBlockActualVisibleWidth int
     firstOfMonth := time.Date(displayYear, displayMonth, 1, 0, 0, 0, 0, cfg.TargetTime.Location()) // Use target time's location for consistency
     lastOfMonth := firstOfMonth.AddDate(0, 1, -1)
     today := cfg.TargetTime // Use cfg.TargetTime for "today" consistency
 
     // Define the consistent visible width for the content area of a single month block.
-    // Week Number Column (4 chars: " Wk " or " 22 ") + 7 Days (7 * 3 chars: " Mo ") = 4 + 21 = 25 visible characters.
+    gridWidth := getMonthGridWidth(cfg)
+    monthBlockActualVisibleWidth := getMonthBlockWidth(cfg)
 
-    if cfg.ShowWeekNum {
-        monthBlockActualVisibleWidth = 25
-    } else {
-        monthBlockActualVisibleWidth = 23
+    var moonDays map[int]string
+    if cfg.ShowMoon {
+        moonDays = MoonPhaseDays(displayYear, displayMonth, cfg.TargetTime.Location())
     }
 
     // Month/Year Header
     monthYearHeaderStr := fmt.Sprintf("%s %d", displayMonth.String(), displayYear)
+    if cfg.ShowZodiac {
+        // The animal of the Chinese year the month ends in
+        monthYearHeaderStr += " " + ChineseZodiac(lastOfMonth)
+    }
     // Calculate padding needed to center the month/year string within monthBlockActualVisibleWidth
     paddingNeeded := monthBlockActualVisibleWidth - len(monthYearHeaderStr) // Calculate based on visible text length
     leftPadding := paddingNeeded / 2
@@ -62,11 +83,10 @@ func GetMonthViewLines(cfg Config, displayMonth time.Month, displayYear int, all
         style_reset)
     lines = append(lines, centeredMonthYearHeader)
 
+    // Day headers start with the configured first day of the week
     var daysHeader []string
-    if cfg.MondayFirst {
-        daysHeader = []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"}
-    } else {
-        daysHeader = []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+    for i := range 7 {
+        daysHeader = append(daysHeader, weekdayAbbrevs[(int(cfg.WeekStart)+i)%7])
     }
 
     headerLine := ""
@@ -92,7 +112,13 @@ func GetMonthViewLines(cfg Config, displayMonth time.Month, displayYear int, all
         if ev.Date.Year() == displayYear && ev.Date.Month() == displayMonth {
             // Normalize event date to the same location as the calendar's current date
             dateOnly := time.Date(ev.Date.Year(), ev.Date.Month(), ev.Date.Day(), 0, 0, 0, 0, cfg.TargetTime.Location())
-            if _, exists := uniqueEventDatesForHighlight[dateOnly]; !exists {
+            if cfg.Milestones && MilestoneLabel(ev) != "" {
+                // Milestones win over other events of the day
+                uniqueEventDatesForHighlight[dateOnly] = EventDisplayColors{
+                    FgColor: milestoneFgColor,
+                    BgColor: milestoneBgColor,
+                }
+            } else if _, exists := uniqueEventDatesForHighlight[dateOnly]; !exists {
                 uniqueEventDatesForHighlight[dateOnly] = EventDisplayColors{
                     FgColor: ev.DisplayColor,
                     BgColor: ev.DisplayBgColor,
@@ -101,30 +127,24 @@ func GetMonthViewLines(cfg Config, displayMonth time.Month, displayYear int, all
         }
     }
 
-    // Calculate starting weekday offset
-    startDayOffset := int(firstOfMonth.Weekday()) // Sunday = 0, ..., Saturday = 6
-    if cfg.MondayFirst {
-        startDayOffset = (startDayOffset - 1 + 7) % 7 // Monday = 0, ..., Sunday = 6
-    }
+    // Calculate starting weekday offset relative to the first day of the week
+    startDayOffset := (int(firstOfMonth.Weekday()) - int(cfg.WeekStart) + 7) % 7
 
     currentDay := 1
     for weekRow := 0; ; weekRow++ {
         rowStr := ""
         // Print week number column (4 visible characters)
         if cfg.ShowWeekNum {
+            // The middle day of the row decides its ISO week, so rows not starting on Monday
+            // get the week most of their days belong to.
             dayForWeekCalc := 1
-            if weekRow == 0 {
-                dayForWeekCalc = 1 // Use 1st of month for the first row (or a reasonable day if month starts mid-week)
+            approxDayInRow := (weekRow * 7) + 1 - startDayOffset + 3
+            if approxDayInRow <= 0 {
+                dayForWeekCalc = 1
+            } else if approxDayInRow > lastOfMonth.Day() {
+                dayForWeekCalc = lastOfMonth.Day()
             } else {
-                // A day approximately in this row for ISO week calculation
-                approxDayInRow := (weekRow * 7) + 1 - startDayOffset
-                if approxDayInRow <= 0 {
-                    dayForWeekCalc = 1
-                } else if approxDayInRow > lastOfMonth.Day() {
-                    dayForWeekCalc = lastOfMonth.Day()
-                } else {
-                    dayForWeekCalc = approxDayInRow
-                }
+                dayForWeekCalc = approxDayInRow
             }
 
             // Only print week number if there are actual days from the month in this row or previous rows had days
@@ -139,6 +159,7 @@ func GetMonthViewLines(cfg Config, displayMonth time.Month, displayYear int, all
         }
 
         hasDaysInRow := false
+        rowFirstDay := currentDay
         for d := range 7 { // Iterate through 7 days of the week
             if weekRow == 0 && d < startDayOffset {
                 rowStr += strings.Repeat(" ", 3) // Padding for days before the 1st of the month (3 visible chars)
@@ -147,20 +168,9 @@ func GetMonthViewLines(cfg Config, displayMonth time.Month, displayYear int, all
             } else {
                 hasDaysInRow = true
                 dayToPrint := currentDay
-                isWeekend := false
                 currentDate := time.Date(displayYear, displayMonth, dayToPrint, 0, 0, 0, 0, cfg.TargetTime.Location())
                 isToday := currentDate.Year() == today.Year() && currentDate.Month() == today.Month() && currentDate.Day() == today.Day()
-
-                weekday := currentDate.Weekday()
-                if cfg.MondayFirst {
-                    if weekday == time.Saturday || weekday == time.Sunday {
-                        isWeekend = true
-                    }
-                } else { // Sunday first
-                    if weekday == time.Sunday || weekday == time.Saturday {
-                        isWeekend = true
-                    }
-                }
+                isWeekend := cfg.WeekendDays[currentDate.Weekday()]
 
                 eventDisplayColors, isEventDay := uniqueEventDatesForHighlight[currentDate]
                 dayStr := fmt.Sprintf("%2d", dayToPrint) // Format to 2 characters, e.g., " 1", "10"
@@ -185,9 +195,20 @@ func GetMonthViewLines(cfg Config, displayMonth time.Month, displayYear int, all
             }
         }
 
-        // Pad the entire row to ensure its visible length matches monthBlockActualVisibleWidth
+        // Pad the entire row to ensure its visible length matches the grid width
         visibleRowLen := len(removeANSI(rowStr))
-        rowStr += strings.Repeat(" ", monthBlockActualVisibleWidth-visibleRowLen)
+        rowStr += strings.Repeat(" ", gridWidth-visibleRowLen)
+
+        if cfg.ShowMoon {
+            // Moon phase column: glyph of a new or full moon falling on one of the row's days
+            moonCell := "   "
+            for day := rowFirstDay; day < currentDay; day++ {
+                if glyph, ok := moonDays[day]; ok {
+                    moonCell = glyph + " "
+                }
+            }
+            rowStr += moonCell
+        }
         lines = append(lines, rowStr) // Removed TrimRight
 
         // Break condition: if no days from the current month were printed in this row,
@@ -202,23 +223,49 @@ func GetMonthViewLines(cfg Config, displayMonth time.Month, displayYear int, all
             break
         }
     }
+
+    if cfg.ShowSummary {
+        // Pad to the maximum of 6 week rows so the summaries of neighbouring months line up
+        for len(lines) < 8 {
+            lines = append(lines, strings.Repeat(" ", monthBlockActualVisibleWidth))
+        }
+        lines = append(lines, getMonthSummaryLine(cfg, firstOfMonth, lastOfMonth, allEvents, monthBlockActualVisibleWidth))
+    }
     return lines
 }
 
+// getMonthSummaryLine returns the footer line with the number of working days, holidays and
+// weekend days of a month. Holidays are events tagged with one of cfg.HolidayTags; only those
+// falling on a working weekday are counted, so the three numbers add up to the days in the month.
+func getMonthSummaryLine(cfg Config, firstOfMonth, lastOfMonth time.Time, allEvents []Event, width int) string {
+    holidays := HolidaySet(allEvents, cfg.HolidayTags)
+    workDays, holidayDays, weekendDays := 0, 0, 0
+    for d := firstOfMonth; !d.After(lastOfMonth); d = d.AddDate(0, 0, 1) {
+        if cfg.WeekendDays[d.Weekday()] {
+            weekendDays++
+        } else if _, isHoliday := holidays[dateKey(d)]; isHoliday {
+            holidayDays++
+        } else {
+            workDays++
+        }
+    }
+
+    summary := fmt.Sprintf(" %sWork%s %s%2d%s %sHol%s %s%d%s %sW/E%s %d",
+        fg_blue, style_reset, style_bold, workDays, style_reset,
+        fg_blue, style_reset, fg_red, holidayDays, style_reset,
+        fg_blue, style_reset, weekendDays)
+    return summary + strings.Repeat(" ", width-len(removeANSI(summary)))
+}
+
 // PrintCalendar renders multiple monthly calendars.
 func PrintCalendar(cfg Config, startMonth time.Month, startYear int, allEvents []Event) {
     // Constants for layout
     var interCalendarSpace int // Spaces between each calendar block in a row
-    var monthBlockActualVisibleWidth int
-
-    // Define the consistent visible width for the content area of a single month block.
-    // Week Number Column (4 chars: " Wk " or " 22 ") + 7 Days (7 * 3 chars: " Mo ") = 4 + 21 = 25 visible characters.
+    monthBlockActualVisibleWidth := getMonthBlockWidth(cfg)
 
     if cfg.ShowWeekNum {
-        monthBlockActualVisibleWidth = 25
         interCalendarSpace = 3
     } else {
-        monthBlockActualVisibleWidth = 23
         interCalendarSpace = 1
     }
 
@@ -275,17 +322,57 @@ func PrintCalendar(cfg Config, startMonth time.Month, startYear int, allEvents [
     }
 }
 
+// FormatSunTimes returns sunrise, sunset and day length of a date at the configured location,
+// e.g. "🌅 07:59  🌇 18:22  (10h23m daylight)".
+func FormatSunTimes(cfg Config, date time.Time) string {
+    loc := cfg.TargetTime.Location()
+    day := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, loc)
+    rise, set, dayLength := SunTimes(day, cfg.Latitude, cfg.Longitude, loc)
+    if rise.IsZero() {
+        if dayLength > 0 {
+            return "☀️ polar day"
+        }
+        return "🌑 polar night"
+    }
+    minutes := int(dayLength.Round(time.Minute).Minutes())
+    return fmt.Sprintf("🌅 %s  🌇 %s  %s(%dh%02dm daylight)%s", rise.Format("15:04"), set.Format("15:04"), fg_blue, minutes/60, minutes%60, style_reset)
+}
+
+// ResolveTimeSpec turns an event's time qualifier into a clock time ("HH:MM") on date.
+// Sunrise/sunset based times need a configured location; without one the qualifier is returned as written.
+func ResolveTimeSpec(cfg Config, spec string, date time.Time) string {
+    matches := reTimeSpec.FindStringSubmatch(spec)
+    if len(matches) == 0 {
+        return spec
+    }
+    if matches[1] != "" { // HH:MM
+        hour, _ := strconv.Atoi(matches[1])
+        minute, _ := strconv.Atoi(matches[2])
+        return fmt.Sprintf("%02d:%02d", hour, minute)
+    }
+    if !cfg.HasLocation {
+        return spec
+    }
+    loc := cfg.TargetTime.Location()
+    rise, set, _ := SunTimes(time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, loc), cfg.Latitude, cfg.Longitude, loc)
+    t := set
+    if matches[3] == "sunrise" {
+        t = rise
+    }
+    if t.IsZero() {
+        return spec // The Sun does not rise or set on this day
+    }
+    offset, _ := strconv.Atoi(matches[4])
+    return t.Add(time.Duration(offset) * time.Minute).Format("15:04")
+}
+
 // PrintEventList renders a combined event list for the displayed period.
 func PrintEventList(cfg Config, startMonth time.Month, startYear int, allEvents []Event) {
     // foundEvents := false
     uniqueEventsForList := make(map[string]Event)
 
-    // Determine the end month and year of the display range
-    endMonth := time.Month((int(startMonth)-1+cfg.NumMonths-1)%12 + 1)
-    endYear  := startYear + ((int(startMonth)-1+cfg.NumMonths-1)/12)
-
-    startDate := time.Date(startYear, startMonth, 1, 0, 0, 0, 0, cfg.TargetTime.Location())
-    endDate   := time.Date(endYear, endMonth, 1, 0, 0, 0, 0, cfg.TargetTime.Location()).AddDate(0, 1, -1) // Last day of the end month
+    // Determine the first and last day of the display range
+    startDate, endDate := getDisplayRange(cfg, startMonth, startYear)
 
     for _, e := range allEvents {
         eventDate := time.Date(e.Date.Year(), e.Date.Month(), e.Date.Day(), 0, 0, 0, 0, cfg.TargetTime.Location())
@@ -311,6 +398,10 @@ func PrintEventList(cfg Config, startMonth time.Month, startYear int, allEvents
     })
 
 
+    if cfg.HasLocation {
+        fmt.Printf("%sSun today:%s %s\n", style_bold, style_reset, FormatSunTimes(cfg, cfg.TargetTime))
+    }
+
     if len(sortedUniqueEvents) > 0 {
         fmt.Printf("%sEvents:%s\n", style_bold, style_reset)
         // foundEvents = true
@@ -331,6 +422,12 @@ func PrintEventList(cfg Config, startMonth time.Month, startYear int, allEvents
             todayStart := time.Date(cfg.TargetTime.Year(), cfg.TargetTime.Month(), cfg.TargetTime.Day(), 0, 0, 0, 0, cfg.TargetTime.Location())
             daysDiff := int(eventDayStart.Sub(todayStart).Hours() / 24)
 
+            // Time of day for timed reminders (e.g. " 16:12" for "at sunset")
+            eventTime := ""
+            if e.TimeSpec != "" {
+                eventTime = " " + ResolveTimeSpec(cfg, e.TimeSpec, e.Date)
+            }
+
             // Use explicit emoji if provided, otherwise fall back to default based on type
             displayEmoji := e.Emoji
             if displayEmoji == "" {
@@ -341,45 +438,29 @@ func PrintEventList(cfg Config, startMonth time.Month, startYear int, allEvents
             // fmt.Printf(" %s%s%2d%s %s, %s %s: %s %s", e.DisplayColor, e.DisplayBgColor, e.Date.Day(), daySuffix, e.Date.Month().String()[:3], e.Date.Weekday().String()[:3], style_reset, displayEmoji, e.Description)
 
             if e.IsAnniversary && !e.AnniDate.IsZero() {
-                 // Relative age (relative to displayed calendar and year)
-                // --------------------------------------------------------
-                age := startYear - e.AnniDate.Year()
-
-                  // Absolute age (it is always from start to today's year)
-                 // --------------------------------------------------------
-                // age := cfg.TargetTime.Year() - e.AnniDate.Year()
-
-                if cfg.TargetTime.Month() < e.AnniDate.Month() ||
-                    (cfg.TargetTime.Month() == e.AnniDate.Month() && cfg.TargetTime.Day() < e.AnniDate.Day()) {
-                    age--
-                }
+                // Age reached on the day of this occurrence
+                age := AnniversaryAge(e)
                 if age >= 0 {
-                    ageSuffix := "th"
-                    switch age{
-                    case 1, 21, 31:
-                        ageSuffix = "st"
-                    case 2, 22:
-                        ageSuffix = "nd"
-                    case 3, 23:
-                        ageSuffix = "rd"
-                    }
 
                     // Use e.DisplayColor and e.DisplayBgColor for the event list output as well
-                    fmt.Printf(" %s%s%s, %02d %s %4d%s  %s %s", e.DisplayColor, e.DisplayBgColor, e.Date.Weekday().String()[:3], e.Date.Day(), e.Date.Month().String()[:3], e.Date.Year(), style_reset, displayEmoji, e.Description)
+                    fmt.Printf(" %s%s%s, %02d %s %4d%s%s  %s %s", e.DisplayColor, e.DisplayBgColor, e.Date.Weekday().String()[:3], e.Date.Day(), e.Date.Month().String()[:3], e.Date.Year(), eventTime, style_reset, displayEmoji, e.Description)
                     // fmt.Printf(" %s%s%s, %2d%s %s %4d%s: %s %s", e.DisplayColor, e.DisplayBgColor, e.Date.Weekday().String()[:3], e.Date.Day(), daySuffix, e.Date.Month().String()[:3], e.Date.Year(), style_reset, displayEmoji, e.Description)
 
                     if e.Type == "birthday" {
-                        fmt.Printf(" (%d%s Birthday)", age, ageSuffix)
+                        fmt.Printf(" (%s Birthday)", Ordinal(age))
                     }
                     if e.Type == "anniversary" {
-                        fmt.Printf(" (%d%s Anniversary)", age, ageSuffix)
+                        fmt.Printf(" (%s Anniversary)", Ordinal(age))
+                    }
+                    if label := MilestoneLabel(e); cfg.Milestones && label != "" {
+                        fmt.Printf(" %s%s %s %s", milestoneFgColor, milestoneBgColor, label, style_reset)
                     }
 
                 } else {
                     show_days_counter = 0
                 }
             } else {
-                fmt.Printf(" %s%s%s, %02d %s %4d%s  %s %s", e.DisplayColor, e.DisplayBgColor, e.Date.Weekday().String()[:3], e.Date.Day(), e.Date.Month().String()[:3], e.Date.Year(), style_reset, displayEmoji, e.Description)
+                fmt.Printf(" %s%s%s, %02d %s %4d%s%s  %s %s", e.DisplayColor, e.DisplayBgColor, e.Date.Weekday().String()[:3], e.Date.Day(), e.Date.Month().String()[:3], e.Date.Year(), eventTime, style_reset, displayEmoji, e.Description)
             }
 
             if show_days_counter > 0 {
@@ -390,6 +471,9 @@ func PrintEventList(cfg Config, startMonth time.Month, startYear int, allEvents
                 } else {
                     fmt.Printf(" %s(%s%d%s %sday%s ago)%s", fg_blue, style_bold, -daysDiff, style_reset, fg_blue, pluralS(-daysDiff), style_reset)
                 }
+                if cfg.ShowSun && cfg.HasLocation {
+                    fmt.Printf("  %s", FormatSunTimes(cfg, e.Date))
+                }
                 fmt.Println()
             }
         }
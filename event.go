@@ -2,6 +2,7 @@ package main
 
 import (
     "bufio"
+    "errors"
     "fmt"
     "os"
     "regexp"
@@ -11,16 +12,54 @@ import (
 )
 
 var (
-    // MM/DOW#N (e.g., 5/1#1 for 1st Monday of May; DOW: 1=Mon, ..., 7=Sun)
-    reNthWeekday = regexp.MustCompile(`^(\d{1,2})/([1-7])#([1-5])$`)
-    // E or E+N or E-N
-    reEaster = regexp.MustCompile(`^E([+-]?)(\d*)$`)
+    // MM/DOW#N (e.g., 5/1#1 for 1st Monday of May; DOW: 1=Mon, ..., 7=Sun; N: 1-5, L for last, -1..-5 from the end)
+    reNthWeekday = regexp.MustCompile(`^(\d{1,2})/([1-7])#(L|-?[1-5])$`)
+    // E or E+N or E-N (Western Easter), OE or OE+N or OE-N (Orthodox Easter)
+    reEaster = regexp.MustCompile(`^(O?E)([+-]?)(\d*)$`)
+    // */D (day D of every month, negative D counts from the end: */-1 is the last day)
+    reMonthlyDay = regexp.MustCompile(`^\*/(-?\d{1,2})$`)
+    // */DOW#N (Nth DOW of every month, e.g. */5#1 for the 1st Friday; N as for MM/DOW#N)
+    reMonthlyNthWeekday = regexp.MustCompile(`^\*/([1-7])#(L|-?[1-5])$`)
     // MM/DD or MM/DD? or MM/DD?YYYY or MM/DD?D[+-]N (D is 0-6 for Sun-Sat)
     reMonthDay = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})(\?(?:(\d{4})|([0-6][+-]\d+)|))?$`)
     // MM/DD/YYYY
     reUsDate = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})/(\d{4})$`)
     // DD-MM-YYYY
     reIsoDate = regexp.MustCompile(`^(\d{1,2})-(\d{1,2})-(\d{4})$`)
+    // H:M/D with optional +N/-N offset and ?D[+-]N postponements (Hebrew month 1=Nisan..13=Adar II)
+    reHebrew = regexp.MustCompile(`^H:(\d{1,2})/(\d{1,2})([+-]\d+)?((?:\?[0-6][+-]\d+)*)$`)
+    // I:M/D with optional +N/-N offset and ?D[+-]N postponements (Hijri month 1=Muharram..12=Dhu al-Hijjah)
+    reIslamic = regexp.MustCompile(`^I:(\d{1,2})/(\d{1,2})([+-]\d+)?((?:\?[0-6][+-]\d+)*)$`)
+    // C:M/D or C:LM/D (leap month) with optional +N/-N offset and ?D[+-]N postponements (Chinese lunisolar month 1-12)
+    reChinese = regexp.MustCompile(`^C:(L?)(\d{1,2})/(\d{1,2})([+-]\d+)?((?:\?[0-6][+-]\d+)*)$`)
+    // Astronomical keyword with optional +N/-N offset and ?D[+-]N postponements (e.g. SOLSTICE_JUNE, FULLMOON+1)
+    reAstronomical = regexp.MustCompile(`^(SOLSTICE_JUNE|SOLSTICE_DECEMBER|EQUINOX_MARCH|EQUINOX_SEPTEMBER|NEWMOON|FULLMOON)([+-]\d+)?((?:\?[0-6][+-]\d+)*)$`)
+
+    // DN or D-N: Nth day of the year, counted from the end if negative (e.g. D256, D-1)
+    reDayOfYear = regexp.MustCompile(`^D(-?\d{1,3})$`)
+    // WNN-D: day D (1=Mon..7=Sun) of ISO week NN (e.g. W10-5 for Friday of week 10)
+    reISOWeekDate = regexp.MustCompile(`^W(\d{1,2})-([1-7])$`)
+    // every N(d|w|m|y) from DATE [until DATE] [count N] (e.g. every 2w from 06-01-2025 count 10)
+    rePeriodic = regexp.MustCompile(`^every\s+(\d+)\s*([dwmy])\s+from\s+(\S+)(?:\s+until\s+(\S+))?(?:\s+count\s+(\d+))?$`)
+    // anchor >DOW, >=DOW, <DOW or <=DOW: the weekday (1=Mon..7=Sun) after, on or after, before or
+    // on or before the date of any other rule (e.g. 11/1#1>2, 12/24<=7, E<3)
+    reRelativeWeekday = regexp.MustCompile(`^(.*\S)\s*(>=|<=|>|<)\s*([1-7])$`)
+
+    // Event ID prefix: "@id rule" (e.g. "@may-day 5/1#1"); IDs are words joined by '-', each starting with a letter
+    reEventID = regexp.MustCompile(`^@([A-Za-z][A-Za-z0-9]*(?:-[A-Za-z][A-Za-z0-9]*)*)\s+(.+)$`)
+
+    // Trailing time qualifier: "<rule> at HH:MM", "<rule> at sunset", "<rule> at sunrise-30m"
+    reTimeQualifier = regexp.MustCompile(`^(.+?)\s+at\s+((?:sunrise|sunset)(?:[+-]\d+m)?|\d{1,2}:\d{2})$`)
+    // Time of a time qualifier: HH and MM, or sunrise/sunset and an offset in minutes
+    reTimeSpec = regexp.MustCompile(`^(?:(\d{1,2}):(\d{2})|(sunrise|sunset)(?:([+-]\d+)m)?)$`)
+    // Observed-holiday qualifier: "<rule> observed next", "<rule> observed nearest", "<rule> observed roll"
+    reObservedQualifier = regexp.MustCompile(`^(.+?)\s+observed\s+(next|nearest|roll)$`)
+    // Business day qualifier: "<rule> bd-" (previous working day) or "<rule> bd+" (next working day)
+    reBusinessDayQualifier = regexp.MustCompile(`^(.+?)\s+bd([+-])$`)
+    // Leap day policy qualifier: "<rule> leap feb28", "<rule> leap mar1", "<rule> leap skip"
+    reLeapQualifier = regexp.MustCompile(`^(.+?)\s+leap\s+(feb28|mar1|skip)$`)
+    // Validity qualifiers: "<rule> from YYYY", "<rule> until DD-MM-YYYY" (or YYYY-MM-DD)
+    reValidityQualifier = regexp.MustCompile(`^(.+?)\s+(from|until)\s+(\d{4}|\d{1,2}-\d{1,2}-\d{4}|\d{4}-\d{2}-\d{2})$`)
 
     // Regex to extract the bracketed configuration part and the remaining description.
     // Group 1: content inside brackets (e.g., "type, fg_color, bg_color, emoji")
@@ -30,49 +69,153 @@ var (
 
 // parseEventDate attempts to parse a date string from an event file.
 // yearContext is the year for which annual events should be resolved.
-// Returns: parsedDate (for yearContext), isAnnual, isAnniversaryCandidate, birthDate (if present), recurrenceRule, specificYearInRule, error
-func parseEventDate(dateStr string, yearContext int) (time.Time, bool, bool, time.Time, string, bool, error) {
+// Most rules occur once per year, but some (e.g. Hijri dates) can occur twice or not at all,
+// so every occurrence in yearContext is returned.
+// cfg provides the options that change how rules are resolved (e.g. cfg.StrictNth), refs the events
+// that @id references point to.
+// Returns: parsedDates (for yearContext), isAnnual, isAnniversaryCandidate, birthDate (if present), recurrenceRule, specificYearInRule, error
+func parseEventDate(dateStr string, yearContext int, cfg Config, refs eventRefs) ([]time.Time, bool, bool, time.Time, string, bool, error) {
     var parsedDate, anniDateVal time.Time
     var isAnnual, isAnniversaryCandidate, specificYearInRule bool
     var recurrenceRule string
 
-    // 1. Easter relative: E, E+N, E-N
-    if matches := reEaster.FindStringSubmatch(dateStr); len(matches) > 0 {
-        easterD := CalculateEaster(yearContext)
+    // 0. Weekday relative to an anchor rule: anchor >DOW, >=DOW, <DOW, <=DOW.
+    // The weekday can move the date across New Year, so the anchor is resolved for the neighbouring years too.
+    if matches := reRelativeWeekday.FindStringSubmatch(dateStr); len(matches) > 0 {
+        dowUser, _ := strconv.Atoi(matches[3]) // 1 (Mon) to 7 (Sun)
+        targetWeekday := time.Weekday(dowUser % 7)
+        dates, err := shiftedDatesAround(yearContext, func(year int) ([]time.Time, error) {
+            anchorDates, anchorAnnual, _, _, _, anchorSpecificYear, err := parseEventDate(matches[1], year, cfg, refs)
+            if err != nil {
+                return nil, fmt.Errorf("anchor of %s: %w", dateStr, err)
+            }
+            isAnnual, specificYearInRule = anchorAnnual, anchorSpecificYear
+            return anchorDates, nil
+        }, func(anchor time.Time) (time.Time, error) {
+            return WeekdayRelativeTo(anchor, matches[2], targetWeekday)
+        })
+        if err != nil {
+            return nil, false, false, time.Time{}, "", false, err
+        }
+        return dates, isAnnual, false, time.Time{}, dateStr, specificYearInRule, nil
+    }
+
+    // 0a. Reference to another event: @id, @id+N, @id-N, relative to the occurrences the referenced
+    // event actually has (after its validity window, leap day policy, exclusions and overrides).
+    // The offset can move the date across New Year, so the referenced event is resolved for the neighbouring years too.
+    if matches := reReference.FindStringSubmatch(dateStr); len(matches) > 0 {
         offset := 0
         if matches[2] != "" {
             offset, _ = strconv.Atoi(matches[2])
         }
-        if matches[1] == "-" {
+        dates, err := shiftedDatesAround(yearContext, func(year int) ([]time.Time, error) {
+            occurrences, err := refs.occurrences(matches[1], year, cfg)
+            if err != nil {
+                return nil, err
+            }
+            var refDates []time.Time
+            for _, ev := range occurrences {
+                refDates = append(refDates, ev.Date)
+                isAnnual, specificYearInRule = ev.IsAnnual, ev.SpecificYearRule
+            }
+            return refDates, nil
+        }, func(refDate time.Time) (time.Time, error) {
+            return refDate.AddDate(0, 0, offset), nil
+        })
+        if err != nil {
+            return nil, false, false, time.Time{}, "", false, err
+        }
+        return dates, isAnnual, false, time.Time{}, dateStr, specificYearInRule, nil
+    }
+
+    // 1. Easter relative: E, E+N, E-N, OE, OE+N, OE-N
+    if matches := reEaster.FindStringSubmatch(dateStr); len(matches) > 0 {
+        easterD := CalculateEaster(yearContext)
+        if matches[1] == "OE" {
+            easterD = CalculateOrthodoxEaster(yearContext)
+        }
+        offset := 0
+        if matches[3] != "" {
+            offset, _ = strconv.Atoi(matches[3])
+        }
+        if matches[2] == "-" {
             offset = -offset
         }
         parsedDate = easterD.AddDate(0, 0, offset)
         isAnnual = true // Easter events are annual relative to the given year's Easter
         recurrenceRule = dateStr
-        return parsedDate, isAnnual, false, time.Time{}, recurrenceRule, false, nil
+        return []time.Time{parsedDate}, isAnnual, false, time.Time{}, recurrenceRule, false, nil
     }
 
-    // 2. Nth DOW of Month: MM/DOW#N (DOW: 1=Mon .. 7=Sun, Nth: 1-5)
+    // 2. Nth DOW of Month: MM/DOW#N (DOW: 1=Mon .. 7=Sun, Nth: 1-5, L (last) or -1..-5 (counted from the end))
     if matches := reNthWeekday.FindStringSubmatch(dateStr); len(matches) > 0 {
         month, _ := strconv.Atoi(matches[1])
         dowUser, _ := strconv.Atoi(matches[2]) // 1 (Mon) to 7 (Sun)
-        nth, _ := strconv.Atoi(matches[3])     // 1 to 5
+        nth := -1                              // L is the last occurrence
+        if matches[3] != "L" {
+            nth, _ = strconv.Atoi(matches[3]) // 1 to 5 or -1 to -5
+        }
 
         if month < 1 || month > 12 {
-            return time.Time{}, false, false, time.Time{}, "", false, fmt.Errorf("invalid month in MM/DOW#N: %s", dateStr)
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid month in MM/DOW#N: %s", dateStr)
         }
         // User DOW (1=Mon..7=Sun) to time.Weekday (Sunday=0..Saturday=6)
         mapUserDowToStd := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
         targetWeekday := mapUserDowToStd[dowUser-1]
 
-        pDate, err := NthWeekdayOfMonth(yearContext, time.Month(month), nth, targetWeekday)
+        pDate, err := NthWeekdayOfMonth(yearContext, time.Month(month), nth, targetWeekday, cfg.StrictNth)
+        if errors.Is(err, ErrNoSuchDate) {
+            return nil, true, false, time.Time{}, dateStr, false, nil // No occurrence this year
+        }
         if err != nil {
-            return time.Time{}, false, false, time.Time{}, "", false, fmt.Errorf("calculating Nth weekday for %s: %w", dateStr, err)
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("calculating Nth weekday for %s: %w", dateStr, err)
         }
         parsedDate = pDate
         isAnnual = true
         recurrenceRule = dateStr
-        return parsedDate, isAnnual, false, time.Time{}, recurrenceRule, false, nil
+        return []time.Time{parsedDate}, isAnnual, false, time.Time{}, recurrenceRule, false, nil
+    }
+
+    // 2a. Monthly: */D (D: 1-31, or -1..-31 counted from the end of the month). Months without day D are skipped.
+    if matches := reMonthlyDay.FindStringSubmatch(dateStr); len(matches) > 0 {
+        day, _ := strconv.Atoi(matches[1])
+        if day == 0 || day < -31 || day > 31 {
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid day in */D rule: %s", dateStr)
+        }
+        var dates []time.Time
+        for month := time.January; month <= time.December; month++ {
+            daysInMonth := time.Date(yearContext, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+            dayOfMonth := day
+            if day < 0 {
+                dayOfMonth = daysInMonth + day + 1
+            }
+            if dayOfMonth >= 1 && dayOfMonth <= daysInMonth {
+                dates = append(dates, time.Date(yearContext, month, dayOfMonth, 0, 0, 0, 0, time.UTC))
+            }
+        }
+        return dates, true, false, time.Time{}, dateStr, false, nil
+    }
+
+    // 2b. Monthly Nth DOW: */DOW#N (DOW: 1=Mon .. 7=Sun, Nth as for MM/DOW#N)
+    if matches := reMonthlyNthWeekday.FindStringSubmatch(dateStr); len(matches) > 0 {
+        dowUser, _ := strconv.Atoi(matches[1]) // 1 (Mon) to 7 (Sun)
+        nth := -1                              // L is the last occurrence
+        if matches[2] != "L" {
+            nth, _ = strconv.Atoi(matches[2])
+        }
+        targetWeekday := time.Weekday(dowUser % 7)
+        var dates []time.Time
+        for month := time.January; month <= time.December; month++ {
+            pDate, err := NthWeekdayOfMonth(yearContext, month, nth, targetWeekday, cfg.StrictNth)
+            if errors.Is(err, ErrNoSuchDate) {
+                continue // No such weekday this month
+            }
+            if err != nil {
+                return nil, false, false, time.Time{}, "", false, fmt.Errorf("calculating Nth weekday for %s: %w", dateStr, err)
+            }
+            dates = append(dates, pDate)
+        }
+        return dates, true, false, time.Time{}, dateStr, false, nil
     }
 
     // 3. MM/DD based: MM/DD, MM/DD?, MM/DD?YYYY, MM/DD?D[+-]N
@@ -82,8 +225,9 @@ func parseEventDate(dateStr string, yearContext int) (time.Time, bool, bool, tim
         yearToUse := yearContext
         isAnnual = true // Initially assume annual unless a specific year is found
 
-        if month < 1 || month > 12 || day < 1 || day > 31 { // Basic validation
-            return time.Time{}, false, false, time.Time{}, "", false, fmt.Errorf("invalid month/day in MM/DD rule: %s", dateStr)
+        // Days beyond the end of the month (e.g. 2/30, 4/31) are errors; 2/29 exists in leap years (2000)
+        if month < 1 || month > 12 || day < 1 || day > DaysInMonth(2000, time.Month(month)) {
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid month/day in MM/DD rule: %s", dateStr)
         }
 
         optYearStr := matches[4]     // This part if present
@@ -95,9 +239,16 @@ func parseEventDate(dateStr string, yearContext int) (time.Time, bool, bool, tim
                 isAnnual = false // Year is specified
                 specificYearInRule = true
             }
+            if day > DaysInMonth(yearToUse, time.Month(month)) {
+                return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid day in MM/DD?YYYY rule (not a leap year): %s", dateStr)
+            }
         }
 
-        baseDate := time.Date(yearToUse, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+        // February 29th in a common year follows the leap day policy
+        baseDate, ok := AnnualDate(yearToUse, time.Month(month), day, cfg.LeapPolicy)
+        if !ok {
+            return nil, isAnnual, false, time.Time{}, dateStr, specificYearInRule, nil // Skipped this year
+        }
 
         if optCondRuleStr != "" { // ?D[+-]N, e.g. 6+2 for "if on Sat, add 2 days"
             // D is 0-6 (Sun-Sat)
@@ -119,7 +270,7 @@ func parseEventDate(dateStr string, yearContext int) (time.Time, bool, bool, tim
                 }
                 recurrenceRule = dateStr // The whole MM/DD?D[+-]N
                 // isAnnual remains true as this rule is checked annually against yearContext's baseDate
-                return parsedDate, isAnnual, false, time.Time{}, recurrenceRule, specificYearInRule, nil
+                return []time.Time{parsedDate}, isAnnual, false, time.Time{}, recurrenceRule, specificYearInRule, nil
             }
         }
 
@@ -127,57 +278,373 @@ func parseEventDate(dateStr string, yearContext int) (time.Time, bool, bool, tim
         parsedDate = baseDate
         // isAnnual is true if specificYearInRule is false. If specificYearInRule is true, isAnnual is false.
         isAnnual = !specificYearInRule
-        return parsedDate, isAnnual, false, time.Time{}, dateStr, specificYearInRule, nil
+        return []time.Time{parsedDate}, isAnnual, false, time.Time{}, dateStr, specificYearInRule, nil
+    }
+
+    // 4. Hebrew calendar: H:M/D, H:M/D+N, H:M/D-N, each optionally followed by ?D[+-]N postponements
+    if matches := reHebrew.FindStringSubmatch(dateStr); len(matches) > 0 {
+        month, _ := strconv.Atoi(matches[1])
+        day, _ := strconv.Atoi(matches[2])
+        // Iyyar, Tammuz, Elul, Tevet and Adar II always have 29 days
+        if month < 1 || month > 13 || day < 1 || day > 30 || (day == 30 && (month == 2 || month == 4 || month == 6 || month == 10 || month == 13)) {
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid month/day in H:M/D rule: %s", dateStr)
+        }
+        offset := 0
+        if matches[3] != "" {
+            offset, _ = strconv.Atoi(matches[3])
+        }
+        dates := shiftedDatesInYear(hebrewDatesAround(yearContext, month, day), offset, matches[4], yearContext)
+        return dates, true, false, time.Time{}, dateStr, false, nil
     }
 
-    // 4. US Date: MM/DD/YYYY
+    // 5. Islamic (tabular Hijri) calendar: I:M/D, I:M/D+N, I:M/D-N, optionally followed by ?D[+-]N postponements.
+    // The Hijri year is about 11 days shorter than the Gregorian one, so a date can occur twice in a year.
+    if matches := reIslamic.FindStringSubmatch(dateStr); len(matches) > 0 {
+        month, _ := strconv.Atoi(matches[1])
+        day, _ := strconv.Atoi(matches[2])
+        // Even months other than Dhu al-Hijjah always have 29 days
+        if month < 1 || month > 12 || day < 1 || day > 30 || (day == 30 && month%2 == 0 && month != 12) {
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid month/day in I:M/D rule: %s", dateStr)
+        }
+        offset := 0
+        if matches[3] != "" {
+            offset, _ = strconv.Atoi(matches[3])
+        }
+        dates := shiftedDatesInYear(islamicDatesAround(yearContext, month, day), offset, matches[4], yearContext)
+        return dates, true, false, time.Time{}, dateStr, false, nil
+    }
+
+    // 6. Chinese lunisolar calendar: C:M/D (C:LM/D for a leap month), C:M/D+N, C:M/D-N,
+    // optionally followed by ?D[+-]N postponements
+    if matches := reChinese.FindStringSubmatch(dateStr); len(matches) > 0 {
+        month, _ := strconv.Atoi(matches[2])
+        day, _ := strconv.Atoi(matches[3])
+        if month < 1 || month > 12 || day < 1 || day > 30 {
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid month/day in C:M/D rule: %s", dateStr)
+        }
+        offset := 0
+        if matches[4] != "" {
+            offset, _ = strconv.Atoi(matches[4])
+        }
+        dates := shiftedDatesInYear(chineseDatesAround(yearContext, month, day, matches[1] == "L"), offset, matches[5], yearContext)
+        return dates, true, false, time.Time{}, dateStr, false, nil
+    }
+
+    // 7. Astronomical events: equinoxes and solstices (yearly), new and full moons (monthly),
+    // dated in the local time zone
+    if matches := reAstronomical.FindStringSubmatch(dateStr); len(matches) > 0 {
+        offset := 0
+        if matches[2] != "" {
+            offset, _ = strconv.Atoi(matches[2])
+        }
+        dates := shiftedDatesInYear(astronomicalDatesAround(yearContext, matches[1], time.Local), offset, matches[3], yearContext)
+        return dates, true, false, time.Time{}, dateStr, false, nil
+    }
+
+    // 8. US Date: MM/DD/YYYY
     if matches := reUsDate.FindStringSubmatch(dateStr); len(matches) > 0 {
         month, _ := strconv.Atoi(matches[1])
         day, _ := strconv.Atoi(matches[2])
         year, _ := strconv.Atoi(matches[3])
-        if month < 1 || month > 12 || day < 1 || day > 31 {
-            return time.Time{}, false, false, time.Time{}, "", false, fmt.Errorf("invalid month/day in MM/DD/YYYY: %s", dateStr)
+        if month < 1 || month > 12 || day < 1 || day > DaysInMonth(year, time.Month(month)) {
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid month/day in MM/DD/YYYY: %s", dateStr)
         }
         parsedDate = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
         isAnnual = false
         isAnniversaryCandidate = true
         anniDateVal = parsedDate
         specificYearInRule = true
-        return parsedDate, isAnnual, isAnniversaryCandidate, anniDateVal, "", specificYearInRule, nil
+        return []time.Time{parsedDate}, isAnnual, isAnniversaryCandidate, anniDateVal, "", specificYearInRule, nil
     }
 
-    // 5. ISO-like Date: DD-MM-YYYY
+    // 9. ISO-like Date: DD-MM-YYYY
     if matches := reIsoDate.FindStringSubmatch(dateStr); len(matches) > 0 {
         day, _ := strconv.Atoi(matches[1])
         month, _ := strconv.Atoi(matches[2])
         year, _ := strconv.Atoi(matches[3])
-        if month < 1 || month > 12 || day < 1 || day > 31 {
-            return time.Time{}, false, false, time.Time{}, "", false, fmt.Errorf("invalid month/day in DD-MM-YYYY: %s", dateStr)
+        if month < 1 || month > 12 || day < 1 || day > DaysInMonth(year, time.Month(month)) {
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid month/day in DD-MM-YYYY: %s", dateStr)
         }
         parsedDate = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
         isAnnual = false
         isAnniversaryCandidate = true
         anniDateVal = parsedDate
         specificYearInRule = true
-        return parsedDate, isAnnual, isAnniversaryCandidate, anniDateVal, "", specificYearInRule, nil
+        return []time.Time{parsedDate}, isAnnual, isAnniversaryCandidate, anniDateVal, "", specificYearInRule, nil
+    }
+
+    // 10. Periodic: every N(d|w|m|y) from DATE [until DATE] [count N] (dates as DD-MM-YYYY or YYYY-MM-DD)
+    if matches := rePeriodic.FindStringSubmatch(dateStr); len(matches) > 0 {
+        n, _ := strconv.Atoi(matches[1])
+        if n < 1 {
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid interval in periodic rule: %s", dateStr)
+        }
+        startDate, err := ParseDateArg(matches[3])
+        if err != nil {
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid start of periodic rule %s: %w", dateStr, err)
+        }
+        var untilDate time.Time
+        if matches[4] != "" {
+            if untilDate, err = ParseDateArg(matches[4]); err != nil {
+                return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid end of periodic rule %s: %w", dateStr, err)
+            }
+        }
+        count := 0
+        if matches[5] != "" {
+            count, _ = strconv.Atoi(matches[5])
+        }
+        dates := PeriodicDatesInYear(startDate, n, matches[2][0], untilDate, count, yearContext)
+        return dates, false, false, time.Time{}, dateStr, false, nil
     }
 
-    return time.Time{}, false, false, time.Time{}, "", false, fmt.Errorf("unknown date format: '%s'", dateStr)
+    // 11. Day of year: DN (1-366) or D-N (-1 is the last day of the year). D366 only occurs in leap years.
+    if matches := reDayOfYear.FindStringSubmatch(dateStr); len(matches) > 0 {
+        n, _ := strconv.Atoi(matches[1])
+        if n == 0 || n < -366 || n > 366 {
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid day of year in DN rule: %s", dateStr)
+        }
+        pDate := time.Date(yearContext, time.January, n, 0, 0, 0, 0, time.UTC)
+        if n < 0 {
+            pDate = time.Date(yearContext+1, time.January, n+1, 0, 0, 0, 0, time.UTC)
+        }
+        if pDate.Year() != yearContext {
+            return nil, true, false, time.Time{}, dateStr, false, nil // No day 366 this year
+        }
+        return []time.Time{pDate}, true, false, time.Time{}, dateStr, false, nil
+    }
+
+    // 12. ISO week date: WNN-D (week 1-53, D: 1=Mon .. 7=Sun).
+    // ISO years start up to 3 days before or after New Year, so the neighbouring ISO years are checked too.
+    if matches := reISOWeekDate.FindStringSubmatch(dateStr); len(matches) > 0 {
+        week, _ := strconv.Atoi(matches[1])
+        dow, _ := strconv.Atoi(matches[2])
+        if week < 1 || week > 53 {
+            return nil, false, false, time.Time{}, "", false, fmt.Errorf("invalid week in WNN-D rule: %s", dateStr)
+        }
+        var dates []time.Time
+        for isoYear := yearContext - 1; isoYear <= yearContext+1; isoYear++ {
+            if week > ISOWeeksInYear(isoYear) {
+                continue // No week 53 in this ISO year
+            }
+            monday, err := GetFirstDayOfISOWeek(isoYear, week)
+            if err != nil {
+                return nil, false, false, time.Time{}, "", false, fmt.Errorf("calculating ISO week for %s: %w", dateStr, err)
+            }
+            if pDate := monday.AddDate(0, 0, dow-1); pDate.Year() == yearContext {
+                dates = append(dates, pDate)
+            }
+        }
+        return dates, true, false, time.Time{}, dateStr, false, nil
+    }
+
+    return nil, false, false, time.Time{}, "", false, fmt.Errorf("unknown date format: '%s'", dateStr)
+}
+
+// parseValidityDate parses the date of a "from"/"until" qualifier. A bare year stands for
+// its first day (from) or its last day (until).
+func parseValidityDate(s string, endOfYear bool) (time.Time, error) {
+    if len(s) == 4 {
+        year, _ := strconv.Atoi(s)
+        if endOfYear {
+            return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC), nil
+        }
+        return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), nil
+    }
+    return ParseDateArg(s)
 }
 
-// LoadEvents reads events from the specified file for a given year context.
-func LoadEvents(filePath string, yearContext int) ([]Event, error) {
+// shiftedDatesAround resolves the base dates of a rule for the years around yearContext, moves each
+// of them with shift and returns the distinct results in yearContext. Rules derived from the dates
+// of another rule use it, as the shift can move a date across New Year.
+func shiftedDatesAround(yearContext int, baseDates func(year int) ([]time.Time, error), shift func(time.Time) (time.Time, error)) ([]time.Time, error) {
+    var dates []time.Time
+    seen := make(map[time.Time]bool) // Base rules with a fixed year give the same dates for every year
+    for year := yearContext - 1; year <= yearContext+1; year++ {
+        base, err := baseDates(year)
+        if err != nil {
+            return nil, err
+        }
+        for _, baseDate := range base {
+            pDate, err := shift(baseDate)
+            if err != nil {
+                return nil, err
+            }
+            if pDate.Year() == yearContext && !seen[pDate] {
+                seen[pDate] = true
+                dates = append(dates, pDate)
+            }
+        }
+    }
+    return dates, nil
+}
+
+// shiftedDatesInYear applies an offset in days and a chain of ?D[+-]N postponements to each
+// candidate date and returns those that end up in year.
+func shiftedDatesInYear(candidates []time.Time, offset int, shifts string, year int) []time.Time {
+    var dates []time.Time
+    for _, d := range candidates {
+        // Postponements look at the date after the offset is applied
+        d = applyWeekdayShifts(d.AddDate(0, 0, offset), shifts)
+        if d.Year() == year {
+            dates = append(dates, d)
+        }
+    }
+    return dates
+}
+
+// eventFile is a parsed events file, resolved for any number of years without reading it again.
+type eventFile struct {
+    definitions []eventDefinition
+    exceptions  []eventException
+    refs        eventRefs
+    reported    map[int]bool // Lines whose date parse error has already been reported
+}
+
+// loadEventFile reads and parses the configured events file (cfg.EventsFile).
+// Malformed lines and reference cycles are reported here, once.
+func loadEventFile(cfg Config) (*eventFile, error) {
+    definitions, exceptions, err := readEventFile(cfg.EventsFile)
+    if err != nil {
+        return nil, err
+    }
+    return &eventFile{
+        definitions: definitions,
+        exceptions:  exceptions,
+        refs:        resolveEventRefs(definitions, exceptions),
+        reported:    make(map[int]bool),
+    }, nil
+}
+
+// eventsForYear returns the events of the file in yearContext.
+// Holidays with an "observed" qualifier falling on a weekend also get a substitute day off,
+// and events with a "bd-"/"bd+" qualifier are moved off non-working days.
+func (f *eventFile) eventsForYear(cfg Config, yearContext int) []Event {
+    events := f.readEvents(cfg, yearContext, true)
+    if !needsNeighbourYears(events) {
+        return events
+    }
+    // Substitutes and moved events can cross New Year, and depend on the neighbouring years' holidays
+    allEvents := events
+    for _, year := range []int{yearContext - 1, yearContext + 1} {
+        allEvents = append(allEvents, f.readEvents(cfg, year, false)...)
+    }
+    allEvents = append(allEvents, ObservedEvents(cfg, allEvents)...)
+    allEvents = MoveToBusinessDays(cfg, allEvents)
+
+    var eventsForYear []Event
+    for _, ev := range allEvents {
+        if ev.Date.Year() == yearContext {
+            eventsForYear = append(eventsForYear, ev)
+        }
+    }
+    return eventsForYear
+}
+
+// needsNeighbourYears reports whether any event has a qualifier resolved across years ("observed", "bd-"/"bd+").
+func needsNeighbourYears(events []Event) bool {
+    for _, ev := range events {
+        if ev.Observed != "" || ev.BusinessDay != 0 {
+            return true
+        }
+    }
+    return false
+}
+
+// eventDefinition is an event line of the events file, with its rule separated from its
+// ID and qualifiers. It is resolved to events for each year separately.
+type eventDefinition struct {
+    lineNumber      int
+    id              string // "@id" if the line has an ID prefix
+    originalDateStr string // Date part of the line as written
+    dateStr         string // The rule without ID and qualifiers
+    timeSpec        string
+    observed        string
+    businessDay     int
+    leapPolicy      string // Overrides cfg.LeapPolicy for this line
+    validFrom       time.Time
+    validUntil      time.Time
+    eventType       string
+    description     string
+    fgColor         string
+    bgColor         string
+    emoji           string
+}
+
+// key returns the key of the definition's events for exclusions and overrides:
+// its ID, or the rule itself if the line has no ID.
+func (def eventDefinition) key() string {
+    if def.id != "" {
+        return def.id
+    }
+    return def.dateStr
+}
+
+// validOn reports whether date is inside the validity window ("from"/"until") of the definition.
+func (def eventDefinition) validOn(date time.Time) bool {
+    return (def.validFrom.IsZero() || !date.Before(def.validFrom)) && (def.validUntil.IsZero() || !date.After(def.validUntil))
+}
+
+// parseRuleQualifiers strips the trailing qualifiers of a rule ("at", "observed", "bd-"/"bd+", "leap",
+// "from"/"until"), in any order, into def and stores the remaining rule in def.dateStr.
+func parseRuleQualifiers(def *eventDefinition, dateStr string) error {
+    var qualifierErr error
+    for qualifierErr == nil {
+        if matches := reTimeQualifier.FindStringSubmatch(dateStr); len(matches) > 0 {
+            // Time of day (e.g. "12/24 at sunset")
+            dateStr, def.timeSpec = matches[1], matches[2]
+            if timeMatches := reTimeSpec.FindStringSubmatch(def.timeSpec); timeMatches[1] != "" {
+                hours, _ := strconv.Atoi(timeMatches[1])
+                minutes, _ := strconv.Atoi(timeMatches[2])
+                if hours > 23 || minutes > 59 {
+                    qualifierErr = fmt.Errorf("invalid time of day %s", def.timeSpec)
+                }
+            }
+        } else if matches := reObservedQualifier.FindStringSubmatch(dateStr); len(matches) > 0 {
+            // Observed-holiday policy (e.g. "12/26 observed roll")
+            dateStr, def.observed = matches[1], matches[2]
+        } else if matches := reBusinessDayQualifier.FindStringSubmatch(dateStr); len(matches) > 0 {
+            // Business day (e.g. "*/15 bd-")
+            dateStr, def.businessDay = matches[1], 1
+            if matches[2] == "-" {
+                def.businessDay = -1
+            }
+        } else if matches := reLeapQualifier.FindStringSubmatch(dateStr); len(matches) > 0 {
+            // Leap day policy (e.g. "29-02-2000 leap feb28")
+            dateStr, def.leapPolicy = matches[1], matches[2]
+        } else if matches := reValidityQualifier.FindStringSubmatch(dateStr); len(matches) > 0 && !rePeriodic.MatchString(dateStr) {
+            // Validity window (e.g. "2/1#1 from 2023"); periodic rules have their own from/until
+            dateStr = matches[1]
+            if matches[2] == "from" {
+                def.validFrom, qualifierErr = parseValidityDate(matches[3], false)
+            } else {
+                def.validUntil, qualifierErr = parseValidityDate(matches[3], true)
+            }
+        } else {
+            break
+        }
+    }
+    if qualifierErr != nil {
+        return qualifierErr
+    }
+    def.dateStr = dateStr
+    return nil
+}
+
+// readEventFile reads the event definitions and the exclusion/override lines of an events file.
+// Malformed lines are reported and skipped. A missing file yields no definitions.
+func readEventFile(filePath string) ([]eventDefinition, []eventException, error) {
     file, err := os.Open(filePath)
     if err != nil {
         if os.IsNotExist(err) {
             fmt.Fprintf(os.Stderr, "Info: Events file '%s' not found. No events will be loaded.\n", filePath)
-            return []Event{}, nil // No events file is not a critical error
+            return nil, nil, nil // No events file is not a critical error
         }
-        return nil, fmt.Errorf("opening events file '%s': %w", filePath, err)
+        return nil, nil, fmt.Errorf("opening events file '%s': %w", filePath, err)
     }
     defer file.Close()
 
-    var events []Event
+    var definitions []eventDefinition
+    var exceptions []eventException
     scanner := bufio.NewScanner(file)
     lineNumber := 0
     for scanner.Scan() {
@@ -187,80 +654,164 @@ func LoadEvents(filePath string, yearContext int) ([]Event, error) {
             continue
         }
 
+        // Exclusion (!KEY OCC) and override (=KEY OCC NEWDATE) lines, applied once all events are resolved
+        if strings.HasPrefix(line, "!") || strings.HasPrefix(line, "=") {
+            exception, err := parseEventException(line)
+            if err != nil {
+                fmt.Fprintf(os.Stderr, "Warning (line %d): Skipping exception: %v\n", lineNumber, err)
+                continue
+            }
+            exceptions = append(exceptions, exception)
+            continue
+        }
+
         parts := strings.SplitN(line, ";", 2)
         if len(parts) != 2 {
             fmt.Fprintf(os.Stderr, "Warning (line %d): Malformed event (missing ';'): %s\n", lineNumber, line)
             continue
         }
 
+        def := eventDefinition{lineNumber: lineNumber}
         dateStr := strings.TrimSpace(parts[0])
-        descPart := strings.TrimSpace(parts[1])
+        def.originalDateStr = dateStr
 
-        var eventType, eventDesc, fgColor, bgColor, emojiChar string
+        // Explicit event ID (e.g. "@may-day 5/1#1"), used by references, exclusions and overrides
+        if matches := reEventID.FindStringSubmatch(dateStr); len(matches) > 0 {
+            def.id, dateStr = "@"+matches[1], matches[2]
+        }
+
+        if err := parseRuleQualifiers(&def, dateStr); err != nil {
+            fmt.Fprintf(os.Stderr, "Warning (line %d): Skipping event due to invalid qualifier ('%s'): %v\n", lineNumber, def.originalDateStr, err)
+            continue
+        }
+
+        descPart := strings.TrimSpace(parts[1])
 
         // Extract the bracketed configuration part and the remaining description
         bracketMatches := reBracketedPart.FindStringSubmatch(descPart)
 
         if len(bracketMatches) == 3 {
             bracketContent := bracketMatches[1]      // e.g., "type, fg_color, bg_color, emoji"
-            eventDesc = strings.TrimSpace(bracketMatches[2]) // The actual description after brackets
+            def.description = strings.TrimSpace(bracketMatches[2]) // The actual description after brackets
 
             // Parse the comma-separated parts within the brackets
             partsInBracket := strings.Split(bracketContent, ",")
 
             if len(partsInBracket) > 0 {
-                eventType = strings.TrimSpace(partsInBracket[0])
+                def.eventType = strings.TrimSpace(partsInBracket[0])
             } else {
-                eventType = "default" // Default type if nothing is specified
+                def.eventType = "default" // Default type if nothing is specified
             }
 
             if len(partsInBracket) > 1 {
-                fgColor = GetFgColorCode(strings.TrimSpace(partsInBracket[1]))
+                def.fgColor = GetFgColorCode(strings.TrimSpace(partsInBracket[1]))
             } else {
-                fgColor = fg_white // Default foreground color
+                def.fgColor = fg_white // Default foreground color
             }
 
             if len(partsInBracket) > 2 {
-                bgColor = GetBgColorCode(strings.TrimSpace(partsInBracket[2]))
+                def.bgColor = GetBgColorCode(strings.TrimSpace(partsInBracket[2]))
             } else {
-                bgColor = "" // Default to no background color
+                def.bgColor = "" // Default to no background color
             }
 
             if len(partsInBracket) > 3 {
                 // The fourth part is assumed to be the emoji character
-                emojiChar = strings.TrimSpace(partsInBracket[3])
+                def.emoji = strings.TrimSpace(partsInBracket[3])
             } else {
-                emojiChar = "" // No explicit emoji provided in config
+                def.emoji = "" // No explicit emoji provided in config
             }
 
         } else {
             // No bracketed part found, treat the whole descPart as description
-            eventDesc = descPart
-            eventType = "default"
-            fgColor = fg_green // Default highlight color
-            bgColor = ""       // Default to no background color
-            emojiChar = ""     // No explicit emoji
+            def.description = descPart
+            def.eventType = "default"
+            def.fgColor = fg_green // Default highlight color
+            def.bgColor = ""       // Default to no background color
+            def.emoji = ""         // No explicit emoji
             fmt.Fprintf(os.Stderr, "Warning (line %d): Event description format unexpected, treating as plain description: %s\n", lineNumber, descPart)
         }
 
-        parsedDate, isAnnual, isBdayCandidate, aDateVal, recRule, specYearRule, err := parseEventDate(dateStr, yearContext)
+        definitions = append(definitions, def)
+    }
+
+    if err := scanner.Err(); err != nil {
+        return nil, nil, fmt.Errorf("reading events file: %w", err)
+    }
+    return definitions, exceptions, nil
+}
+
+// readEvents resolves the event definitions of the file for yearContext.
+// Rules that cannot be resolved are skipped, with a warning (once per line) if report is set.
+func (f *eventFile) readEvents(cfg Config, yearContext int, report bool) []Event {
+    var events []Event
+    for _, def := range f.definitions {
+        eventsOfDef, err := definitionEvents(cfg, def, yearContext, f.refs)
         if err != nil {
-            fmt.Fprintf(os.Stderr, "Warning (line %d): Skipping event due to date parse error ('%s'): %v\n", lineNumber, dateStr, err)
+            if report && !f.reported[def.lineNumber] {
+                f.reported[def.lineNumber] = true
+                fmt.Fprintf(os.Stderr, "Warning (line %d): Skipping event due to date parse error ('%s'): %v\n", def.lineNumber, def.dateStr, err)
+            }
             continue
         }
+        events = append(events, eventsOfDef...)
+    }
+    return applyEventExceptions(events, f.exceptions)
+}
 
-        // Finalize event date and birthday status
+// definitionEvents resolves one event definition for yearContext: its occurrences inside the
+// validity window, and the day-count milestones of birthdays and anniversaries.
+// Exclusions and overrides are left to the caller.
+func definitionEvents(cfg Config, def eventDefinition, yearContext int, refs eventRefs) ([]Event, error) {
+    ruleCfg := cfg
+    if def.leapPolicy != "" {
+        ruleCfg.LeapPolicy = def.leapPolicy
+    }
+    parsedDates, isAnnual, isBdayCandidate, aDateVal, recRule, specYearRule, err := parseEventDate(def.dateStr, yearContext, ruleCfg, refs)
+    if err != nil {
+        return nil, err
+    }
+
+    // Finalize birthday status
+    isActualAnniversary := (strings.ToLower(def.eventType) == "birthday" || strings.ToLower(def.eventType) == "anniversary") && isBdayCandidate && !aDateVal.IsZero()
+    if isActualAnniversary {
+        isAnnual = true // Anniversarys are effectively annual occurrences
+    }
+    baseEvent := Event{
+        OriginalDateStr:  def.originalDateStr,
+        Description:      def.description,
+        Type:             def.eventType,
+        IsAnnual:         isAnnual,
+        IsAnniversary:    isActualAnniversary,
+        AnniDate:         aDateVal, // Store the original birth date
+        RecurrenceRule:   recRule,
+        SpecificYearRule: specYearRule,
+        DisplayColor:     def.fgColor, // Store the determined foreground color
+        DisplayBgColor:   def.bgColor, // Store the determined background color
+        Emoji:            def.emoji,   // Store the explicit emoji character
+        TimeSpec:         def.timeSpec,
+        Observed:         def.observed,
+        BusinessDay:      def.businessDay,
+        ID:               def.key(),
+    }
+
+    var events []Event
+    for _, parsedDate := range parsedDates {
+        // Finalize event date
         actualEventDate := parsedDate
-        isActualAnniversary := (strings.ToLower(eventType) == "birthday" || strings.ToLower(eventType) == "anniversary") && isBdayCandidate && !aDateVal.IsZero()
 
         // If it's an annual event (not fixed to a specific year by its rule),
         // its date should be in the yearContext.
         // If it has a specific year in its rule (specYearRule=true), its date is fixed.
         // For birthdays, aDateVal holds the birth year. The event occurs annually.
         if isActualAnniversary {
-            // Anniversary occurs on aDateVal.Month and aDateVal.Day in yearContext
-            actualEventDate = time.Date(yearContext, aDateVal.Month(), aDateVal.Day(), 0, 0, 0, 0, time.UTC)
-            isAnnual = true // Anniversarys are effectively annual occurrences
+            // Anniversary occurs on aDateVal.Month and aDateVal.Day in yearContext,
+            // with February 29th in common years following the leap day policy
+            anniversary, ok := AnnualDate(yearContext, aDateVal.Month(), aDateVal.Day(), ruleCfg.LeapPolicy)
+            if !ok {
+                continue
+            }
+            actualEventDate = anniversary
         } else if isAnnual && actualEventDate.Year() != yearContext {
             // Ensure annual non-birthday events are set for the correct yearContext
             actualEventDate = time.Date(yearContext, actualEventDate.Month(), actualEventDate.Day(), 0, 0, 0, 0, time.UTC)
@@ -268,27 +819,32 @@ func LoadEvents(filePath string, yearContext int) ([]Event, error) {
             // If rule specified a year and it's not yearContext, and it's NOT a birthday, then skip.
             continue
         }
-
-        event := Event{
-            Date:             actualEventDate,
-            OriginalDateStr:  dateStr,
-            Description:      eventDesc,
-            Type:             eventType,
-            IsAnnual:         isAnnual,
-            IsAnniversary:    isActualAnniversary,
-            AnniDate:         aDateVal, // Store the original birth date
-            RecurrenceRule:   recRule,
-            SpecificYearRule: specYearRule,
-            DisplayColor:     fgColor,   // Store the determined foreground color
-            DisplayBgColor:   bgColor,   // Store the determined background color
-            Emoji:            emojiChar, // Store the explicit emoji character
+        if !def.validOn(actualEventDate) {
+            continue // Outside the validity window of the rule
         }
+
+        event := baseEvent
+        event.Date = actualEventDate
         events = append(events, event)
     }
 
-    if err := scanner.Err(); err != nil {
-        return nil, fmt.Errorf("reading events file: %w", err)
+    // Day-count milestones (e.g. 10,000 days) of birthdays and anniversaries falling in yearContext.
+    // They count from the original date, so they do not depend on the yearly occurrence (e.g. a
+    // February 29th birthday skipped in a common year), and have their own key (e.g. "@bob:10000d").
+    if isActualAnniversary {
+        for _, milestone := range cfg.DayMilestones[strings.ToLower(def.eventType)] {
+            milestoneDate := aDateVal.AddDate(0, 0, milestone.Days)
+            if milestoneDate.Year() != yearContext || !def.validOn(milestoneDate) {
+                continue
+            }
+            milestoneEvent := baseEvent
+            milestoneEvent.Date = milestoneDate
+            milestoneEvent.Description = fmt.Sprintf("%s (%s)", def.description, milestone.Label)
+            milestoneEvent.IsAnnual = false
+            milestoneEvent.IsAnniversary = false // No age to show
+            milestoneEvent.ID = def.key() + ":" + milestone.Key
+            events = append(events, milestoneEvent)
+        }
     }
     return events, nil
 }
-
@@ -0,0 +1,108 @@
+package main
+
+import (
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+)
+
+var (
+    // !KEY OCC: cancel the occurrences of an event in year YYYY or on date DD-MM-YYYY
+    reExclusion = regexp.MustCompile(`^!(\S+)\s+(\d{4}|\d{1,2}-\d{1,2}-\d{4})$`)
+    // =KEY OCC NEWDATE: move the occurrences in year YYYY or on date DD-MM-YYYY to NEWDATE ('-' keeps the date)
+    reOverride = regexp.MustCompile(`^=(\S+)\s+(\d{4}|\d{1,2}-\d{1,2}-\d{4})\s+(\d{1,2}-\d{1,2}-\d{4}|-)$`)
+)
+
+// eventException cancels or changes occurrences of the events with a given key (Event.ID).
+type eventException struct {
+    key         string
+    year        int       // Occurrences in this year, if date is zero
+    date        time.Time // The occurrence on this date
+    cancel      bool      // Exclusion: the occurrence is dropped
+    newDate     time.Time // Override: new date of the occurrence (zero keeps the date)
+    description string    // Override: new description (empty keeps the description)
+}
+
+// parseEventException parses an exclusion line ("!5/1#1 2026 ; optional comment") or an
+// override line ("=@may-day 2026 05-05-2026 ; optional new description").
+func parseEventException(line string) (eventException, error) {
+    parts := strings.SplitN(line, ";", 2)
+    spec := strings.TrimSpace(parts[0])
+
+    var exception eventException
+    var occurrence string
+    if matches := reExclusion.FindStringSubmatch(spec); len(matches) > 0 {
+        exception.key, occurrence = matches[1], matches[2]
+        exception.cancel = true
+    } else if matches := reOverride.FindStringSubmatch(spec); len(matches) > 0 {
+        exception.key, occurrence = matches[1], matches[2]
+        if matches[3] != "-" {
+            newDate, err := ParseDateArg(matches[3])
+            if err != nil {
+                return exception, fmt.Errorf("invalid new date in '%s': %w", spec, err)
+            }
+            exception.newDate = newDate
+        }
+        if len(parts) == 2 {
+            exception.description = strings.TrimSpace(parts[1])
+        }
+    } else {
+        return exception, fmt.Errorf("unknown exclusion or override format: '%s'", spec)
+    }
+
+    if len(occurrence) == 4 {
+        exception.year, _ = strconv.Atoi(occurrence)
+    } else {
+        date, err := ParseDateArg(occurrence)
+        if err != nil {
+            return exception, fmt.Errorf("invalid occurrence in '%s': %w", spec, err)
+        }
+        exception.date = date
+    }
+    return exception, nil
+}
+
+// matches reports whether the exception applies to the occurrence ev.
+func (x eventException) matches(ev Event) bool {
+    if ev.ID != x.key {
+        return false
+    }
+    if !x.date.IsZero() {
+        return dateKey(ev.Date).Equal(x.date)
+    }
+    return ev.Date.Year() == x.year
+}
+
+// applyEventExceptions drops the excluded occurrences and changes the overridden ones.
+// When several exceptions match an occurrence, the last one in the file wins.
+func applyEventExceptions(events []Event, exceptions []eventException) []Event {
+    if len(exceptions) == 0 {
+        return events
+    }
+    var result []Event
+    for _, ev := range events {
+        var applied *eventException
+        for i := range exceptions {
+            if exceptions[i].matches(ev) {
+                applied = &exceptions[i]
+            }
+        }
+        if applied == nil {
+            result = append(result, ev)
+            continue
+        }
+        if applied.cancel {
+            continue
+        }
+        if !applied.newDate.IsZero() {
+            ev.Date = applied.newDate
+        }
+        if applied.description != "" {
+            ev.Description = applied.description
+        }
+        result = append(result, ev)
+    }
+    return result
+}
@@ -10,20 +10,16 @@ import (
 func main() {
     currentTime := time.Now() // Use system's current time
 
-    // Default configuration
-    cfg := Config{
-        Year:        currentTime.Year(),
-        Month:       currentTime.Month(),
-        Week:        0, // Will be checked if > 0
-        MondayFirst: true,
-        EventsFile:  "events.txt", // Default events file name
-        ShowWeekNum: true,
-        TargetTime:  currentTime, // Reference time for age/countdown
-        NumMonths:   1,           // Default to showing 1 month
-        NumColumns:  3,
-        DisplayMode: DisplayBoth, // Default to showing both calendar and events
+    // Subcommands (e.g. "workdays") have their own flags
+    if len(os.Args) > 1 {
+        if command, ok := commands[os.Args[1]]; ok {
+            os.Exit(command(os.Args[2:]))
+        }
     }
 
+    // Default configuration
+    cfg := defaultConfig(currentTime)
+
     // Command-line flags
     yearFlag    := flag.Int("y",  0, "Year for the calendar (default: current year). Also used with -week.")
     monthFlag   := flag.Int("m",  0, "Month for the calendar (1-12) (default: current month).")
@@ -31,10 +27,25 @@ func main() {
     monthsFlag  := flag.Int("mn", 1, "Number of months to display (1, 3, 6, or 12).")
     columnsFlag := flag.Int("c",  3, "Number of columns to display (1, 3, 4, 6, or 12).")
     displayFlag := flag.String("d", DisplayBoth, "What to display: 'calendar', 'events', or 'both' (default).") // New display flag
+    fromFlag    := flag.String("from", "", "First day of a date range to display (YYYY-MM-DD). Overrides -m, -w and -mn.")
+    toFlag      := flag.String("to",   "", "Last day of a date range to display (YYYY-MM-DD). Overrides -m, -w and -mn.")
+    firstFlag   := flag.String("first",   "", "First day of the week, e.g. 'sun' or 'sat'. Overrides -monday.")
+    weekendFlag := flag.String("weekend", "sat,sun", "Comma-separated weekend days, e.g. 'fri,sat' or 'fri,sat,sun'.")
 
     flag.BoolVar(&cfg.MondayFirst,  "monday", cfg.MondayFirst, "Set Monday as the first day of the week.")
     flag.StringVar(&cfg.EventsFile, "f",      cfg.EventsFile,  "Path to the events file.")
     flag.BoolVar(&cfg.ShowWeekNum,  "wk",     cfg.ShowWeekNum, "Show week numbers.")
+    flag.BoolVar(&cfg.StrictNth,    "strict", cfg.StrictNth,   "MM/DOW#5 rules skip months without a 5th occurrence instead of using the last one.")
+    flag.StringVar(&cfg.LeapPolicy, "leap",   cfg.LeapPolicy,  "Where February 29th birthdays and rules fall in common years: 'feb28', 'mar1' or 'skip'.")
+    flag.BoolVar(&cfg.Milestones,   "ms",     cfg.Milestones,  "Label and highlight round birthdays and silver, golden, ... anniversaries.")
+    flag.BoolVar(&cfg.ShowSummary,  "sum",    cfg.ShowSummary, "Show working days, holidays and weekend days under each month.")
+    flag.BoolVar(&cfg.ShowZodiac,   "zodiac", cfg.ShowZodiac,  "Show the Chinese zodiac animal of the year in the month header.")
+    flag.BoolVar(&cfg.ShowMoon,     "moon",   cfg.ShowMoon,    "Show a column with the new (🌑) and full (🌕) moons of each week.")
+    tagsFlag    := flag.String("t", "", "Comma-separated event types counted as non-working days (e.g. 'ie'), used by -sum and skipped by bd-/bd+ rules.")
+    dmFlag      := flag.String("dm", "", "Day-count milestones per event type, e.g. 'birthday:1000d,10000d,500w;anniversary:1000d'.")
+    flag.Float64Var(&cfg.Latitude,  "lat",    cfg.Latitude,    "Latitude of your location in degrees (north positive), for sunrise/sunset (needs -lon).")
+    flag.Float64Var(&cfg.Longitude, "lon",    cfg.Longitude,   "Longitude of your location in degrees (east positive), for sunrise/sunset (needs -lat).")
+    flag.BoolVar(&cfg.ShowSun,      "sun",    cfg.ShowSun,     "Show sunrise and sunset for every listed event day (needs -lat and -lon).")
 
     flag.Usage = func() {
         fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
@@ -47,6 +58,11 @@ func main() {
         fmt.Fprintf(os.Stderr, "  %s -m 7 -y 2025 -mn 3\n", os.Args[0])
         fmt.Fprintf(os.Stderr, "  %s -d calendar\n", os.Args[0]) // New example
         fmt.Fprintf(os.Stderr, "  %s -d events -f my_events.txt\n", os.Args[0]) // New example
+        fmt.Fprintf(os.Stderr, "  %s --from 2025-11-15 --to 2026-02-10\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, "  %s -first sat -weekend fri,sat\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, "  %s -mn 12 -sum -t ie\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, "  %s -lat 53.35 -lon -6.26 -sun\n", os.Args[0])
+        fmt.Fprintf(os.Stderr, "  %s -mn 12 -dm 'birthday:1000d,10000d,500w'\n", os.Args[0])
     }
     flag.Parse()
 
@@ -112,26 +128,109 @@ func main() {
         os.Exit(1)
     }
 
-    // Determine the actual starting month and year for display
-    displayMonth, displayYear := getDisplayMonthYear(cfg)
+    // Process leap day policy flag
+    leapPolicy, err := ParseLeapPolicy(cfg.LeapPolicy)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Error: Invalid -leap value: %v\n", err)
+        flag.Usage()
+        os.Exit(1)
+    }
+    cfg.LeapPolicy = leapPolicy
+
+    // Process day-count milestones flag
+    if *dmFlag != "" {
+        cfg.DayMilestones, err = ParseDayMilestones(*dmFlag)
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "Error: Invalid -dm value: %v\n", err)
+            flag.Usage()
+            os.Exit(1)
+        }
+    }
 
-    // Load events for the range of years that will be displayed.
-    // If displaying multiple months, especially 12, it might span two calendar years.
-    yearsToLoad := []int{displayYear}
-    if cfg.NumMonths > 1 && (int(displayMonth)+cfg.NumMonths-1 > 12) {
-        // If the range of months extends into the next year, add the next year to load events for it.
-        yearsToLoad = append(yearsToLoad, displayYear+1)
+    // A location needs both coordinates
+    latGiven, lonGiven := false, false
+    flag.Visit(func(f *flag.Flag) {
+        latGiven = latGiven || f.Name == "lat"
+        lonGiven = lonGiven || f.Name == "lon"
+    })
+    if latGiven != lonGiven {
+        fmt.Fprintf(os.Stderr, "Error: A location needs both -lat and -lon.\n")
+        flag.Usage()
+        os.Exit(1)
+    }
+    cfg.HasLocation = latGiven && lonGiven
+    if cfg.Latitude < -90 || cfg.Latitude > 90 || cfg.Longitude < -180 || cfg.Longitude > 180 {
+        fmt.Fprintf(os.Stderr, "Error: Invalid location %g, %g. Latitude must be between -90 and 90, longitude between -180 and 180.\n", cfg.Latitude, cfg.Longitude)
+        flag.Usage()
+        os.Exit(1)
     }
 
-    var allEvents []Event
-    for _, year := range yearsToLoad {
-        eventsForYear, err := LoadEvents(cfg.EventsFile, year)
+    // Process week layout flags
+    if cfg.MondayFirst {
+        cfg.WeekStart = time.Monday
+    } else {
+        cfg.WeekStart = time.Sunday
+    }
+    if *firstFlag != "" {
+        weekStart, err := ParseWeekday(*firstFlag)
         if err != nil {
-            fmt.Fprintf(os.Stderr, "Warning: Could not load events for year %d: %v\n", year, err)
-            // Continue, don't exit, just print a warning
+            fmt.Fprintf(os.Stderr, "Error: Invalid -first value: %v\n", err)
+            flag.Usage()
+            os.Exit(1)
         }
-        allEvents = append(allEvents, eventsForYear...)
+        cfg.WeekStart = weekStart
+    }
+    weekendDays, err := ParseWeekdaySet(*weekendFlag)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Error: Invalid -weekend value: %v\n", err)
+        flag.Usage()
+        os.Exit(1)
     }
+    cfg.WeekendDays = weekendDays
+    cfg.HolidayTags = ParseTagList(*tagsFlag)
+
+    // Process date range flags
+    if *fromFlag != "" || *toFlag != "" {
+        loc := cfg.TargetTime.Location()
+        if *fromFlag != "" {
+            fromDate, err := ParseDateArg(*fromFlag)
+            if err != nil {
+                fmt.Fprintf(os.Stderr, "Error: Invalid -from value: %v\n", err)
+                flag.Usage()
+                os.Exit(1)
+            }
+            cfg.FromDate = time.Date(fromDate.Year(), fromDate.Month(), fromDate.Day(), 0, 0, 0, 0, loc)
+        } else {
+            // Range without a start begins with the selected month
+            startMonth, startYear := getDisplayMonthYear(cfg)
+            cfg.FromDate = time.Date(startYear, startMonth, 1, 0, 0, 0, 0, loc)
+        }
+        if *toFlag != "" {
+            toDate, err := ParseDateArg(*toFlag)
+            if err != nil {
+                fmt.Fprintf(os.Stderr, "Error: Invalid -to value: %v\n", err)
+                flag.Usage()
+                os.Exit(1)
+            }
+            cfg.ToDate = time.Date(toDate.Year(), toDate.Month(), toDate.Day(), 0, 0, 0, 0, loc)
+        } else {
+            // Range without an end covers -mn months starting with the month of -from
+            cfg.ToDate = time.Date(cfg.FromDate.Year(), cfg.FromDate.Month(), 1, 0, 0, 0, 0, loc).AddDate(0, cfg.NumMonths, -1)
+        }
+        if cfg.ToDate.Before(cfg.FromDate) {
+            fmt.Fprintf(os.Stderr, "Error: -to date %s is before -from date %s.\n", cfg.ToDate.Format("2006-01-02"), cfg.FromDate.Format("2006-01-02"))
+            os.Exit(1)
+        }
+        cfg.Week = 0
+        cfg.NumMonths = MonthsBetween(cfg.FromDate, cfg.ToDate) // Every month touching the range
+    }
+
+    // Determine the actual starting month and year for display
+    displayMonth, displayYear := getDisplayMonthYear(cfg)
+    rangeStart, rangeEnd := getDisplayRange(cfg, displayMonth, displayYear)
+
+    // Load events for every year touched by the displayed range.
+    allEvents := loadEventsForYears(cfg, rangeStart.Year(), rangeEnd.Year())
 
     // Print based on DisplayMode
     if cfg.DisplayMode == DisplayCalendar || cfg.DisplayMode == DisplayBoth {
@@ -0,0 +1,81 @@
+package main
+
+import (
+    "sort"
+    "strings"
+    "time"
+)
+
+// Substitution policies of the "observed" qualifier for holidays falling on a weekend day
+const (
+    ObservedNext    = "next"    // Next working day that is not a holiday of the same type
+    ObservedNearest = "nearest" // Nearest day that is not a weekend day (e.g. Saturday -> Friday, Sunday -> Monday)
+    ObservedRoll    = "roll"    // Like "next", also skipping days already taken by other substitutes (UK style)
+)
+
+// maxObservedShift limits the search for a substitute day.
+const maxObservedShift = 31
+
+// ObservedEvents returns the substitute days for the events with an "observed" qualifier that
+// fall on a weekend day. Substitution is resolved for all holidays of one type at once, in date
+// order, so events should cover the years around the displayed ones too. The original events are
+// kept; a substitute is a copy with "(observed)" added to the description.
+func ObservedEvents(cfg Config, events []Event) []Event {
+    byType := make(map[string][]Event)
+    for _, ev := range events {
+        eventType := strings.ToLower(ev.Type)
+        byType[eventType] = append(byType[eventType], ev)
+    }
+
+    var substitutes []Event
+    for _, typeEvents := range byType {
+        sort.SliceStable(typeEvents, func(i, j int) bool { return typeEvents[i].Date.Before(typeEvents[j].Date) })
+
+        holidays := make(map[time.Time]bool) // Days of the holidays themselves
+        for _, ev := range typeEvents {
+            holidays[dateKey(ev.Date)] = true
+        }
+        taken := make(map[time.Time]bool) // Days given to substitutes so far (for "roll")
+
+        for _, ev := range typeEvents {
+            if ev.Observed == "" || !cfg.WeekendDays[ev.Date.Weekday()] {
+                continue
+            }
+            day, found := observedDay(cfg, dateKey(ev.Date), ev.Observed, holidays, taken)
+            if !found {
+                continue
+            }
+            taken[day] = true
+            substitute := ev
+            substitute.Date = day
+            substitute.Description = ev.Description + " (observed)"
+            substitute.Observed = ""
+            substitutes = append(substitutes, substitute)
+        }
+    }
+
+    sort.SliceStable(substitutes, func(i, j int) bool { return substitutes[i].Date.Before(substitutes[j].Date) })
+    return substitutes
+}
+
+// observedDay finds the substitute for a holiday on date according to policy.
+func observedDay(cfg Config, date time.Time, policy string, holidays, taken map[time.Time]bool) (time.Time, bool) {
+    for shift := 1; shift <= maxObservedShift; shift++ {
+        switch policy {
+        case ObservedNearest:
+            // Look forward first, so ties move the holiday to the later day
+            for _, d := range []time.Time{date.AddDate(0, 0, shift), date.AddDate(0, 0, -shift)} {
+                if !cfg.WeekendDays[d.Weekday()] {
+                    return d, true
+                }
+            }
+        case ObservedNext, ObservedRoll:
+            d := date.AddDate(0, 0, shift)
+            if cfg.WeekendDays[d.Weekday()] || holidays[d] || (policy == ObservedRoll && taken[d]) {
+                continue
+            }
+            return d, true
+        }
+    }
+    return time.Time{}, false
+}
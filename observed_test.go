@@ -0,0 +1,37 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestObservedDay(t *testing.T) {
+    // Christmas 2027 falls on a Saturday, Boxing Day on a Sunday
+    christmas, boxingDay := date(2027, time.December, 25), date(2027, time.December, 26)
+    holidays := map[time.Time]bool{christmas: true, boxingDay: true}
+
+    tests := []struct {
+        name     string
+        date     time.Time
+        policy   string
+        holidays map[time.Time]bool
+        taken    map[time.Time]bool
+        want     time.Time // Zero if no substitute is found
+    }{
+        {"next", christmas, ObservedNext, holidays, nil, date(2027, time.December, 27)},
+        {"next ignores other substitutes", boxingDay, ObservedNext, holidays, map[time.Time]bool{date(2027, time.December, 27): true}, date(2027, time.December, 27)},
+        {"next skips holidays", christmas, ObservedNext, map[time.Time]bool{christmas: true, date(2027, time.December, 27): true}, nil, date(2027, time.December, 28)},
+        {"roll", christmas, ObservedRoll, holidays, map[time.Time]bool{}, date(2027, time.December, 27)},
+        {"roll skips other substitutes", boxingDay, ObservedRoll, holidays, map[time.Time]bool{date(2027, time.December, 27): true}, date(2027, time.December, 28)},
+        {"nearest on a Saturday", christmas, ObservedNearest, holidays, nil, date(2027, time.December, 24)},
+        {"nearest on a Sunday", boxingDay, ObservedNearest, holidays, nil, date(2027, time.December, 27)},
+        {"unknown policy", christmas, "later", holidays, nil, time.Time{}},
+    }
+    cfg := defaultConfig(christmas)
+    for _, tt := range tests {
+        got, found := observedDay(cfg, tt.date, tt.policy, tt.holidays, tt.taken)
+        if found != !tt.want.IsZero() || !got.Equal(tt.want) {
+            t.Errorf("%s: observedDay(%s, %q) = %s, %t, want %s", tt.name, tt.date.Format("2006-01-02"), tt.policy, got.Format("2006-01-02"), found, tt.want.Format("2006-01-02"))
+        }
+    }
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "regexp"
+    "strings"
+)
+
+var (
+    // @id, @id+N or @id-N: N days after/before each date of the event with that ID (e.g. @thanksgiving+1)
+    reReference = regexp.MustCompile(`^(@[A-Za-z][A-Za-z0-9]*(?:-[A-Za-z][A-Za-z0-9]*)*)([+-]\d+)?$`)
+    // Any event ID mentioned in a rule (e.g. both in "@thanksgiving+1" and "@thanksgiving>5")
+    reReferencedID = regexp.MustCompile(`@[A-Za-z][A-Za-z0-9]*(?:-[A-Za-z][A-Za-z0-9]*)*`)
+)
+
+// eventRefs gives other rules access to the events with an explicit ID ("@id").
+type eventRefs struct {
+    definitions map[string]*eventDefinition // First definition of each ID; nil for IDs in a reference cycle
+    exceptions  []eventException            // Exclusions and overrides, which referenced events follow too
+}
+
+// resolveEventRefs collects the definitions of the events with an explicit ID and checks the references
+// between them. Reference cycles (e.g. "@a @b+1" and "@b @a-1") are reported and their IDs
+// become unusable, so every remaining reference can be resolved recursively.
+func resolveEventRefs(definitions []eventDefinition, exceptions []eventException) eventRefs {
+    refs := eventRefs{definitions: make(map[string]*eventDefinition), exceptions: exceptions}
+    var ids []string // In file order, so cycles are reported in a stable order
+    for i, def := range definitions {
+        if def.id == "" {
+            continue
+        }
+        if _, exists := refs.definitions[def.id]; !exists {
+            refs.definitions[def.id] = &definitions[i]
+            ids = append(ids, def.id)
+        }
+    }
+
+    // Depth-first search; an ID met again while still on the path closes a cycle
+    const (
+        unvisited = iota
+        visiting
+        done
+    )
+    state := make(map[string]int)
+    var path []string
+    cyclic := make(map[string]bool)
+    var visit func(id string)
+    visit = func(id string) {
+        state[id] = visiting
+        path = append(path, id)
+        for _, ref := range reReferencedID.FindAllString(refs.definitions[id].dateStr, -1) {
+            if _, defined := refs.definitions[ref]; !defined {
+                continue // Reported when the rule is resolved
+            }
+            switch state[ref] {
+            case unvisited:
+                visit(ref)
+            case visiting:
+                start := len(path) - 1
+                for path[start] != ref {
+                    start--
+                }
+                cycle := append(append([]string(nil), path[start:]...), ref)
+                fmt.Fprintf(os.Stderr, "Warning (line %d): Reference cycle: %s\n", refs.definitions[ref].lineNumber, strings.Join(cycle, " -> "))
+                for _, cycleID := range cycle {
+                    cyclic[cycleID] = true
+                }
+            }
+        }
+        path = path[:len(path)-1]
+        state[id] = done
+    }
+    for _, id := range ids {
+        if state[id] == unvisited {
+            visit(id)
+        }
+    }
+
+    for id := range cyclic {
+        refs.definitions[id] = nil
+    }
+    return refs
+}
+
+// occurrences returns the events of the event with ID id in yearContext, as listed: inside its
+// validity window and with its exclusions and overrides applied, but without its day-count milestones.
+// The event is resolved with cfg, unless it has its own leap day policy.
+// Events moved by "bd-"/"bd+" cannot be referenced: their dates depend on the holidays of the whole
+// file, which may include the referencing events themselves.
+func (refs eventRefs) occurrences(id string, yearContext int, cfg Config) ([]Event, error) {
+    def, defined := refs.definitions[id]
+    if !defined {
+        return nil, fmt.Errorf("unknown event ID %s", id)
+    }
+    if def == nil {
+        return nil, fmt.Errorf("event ID %s is part of a reference cycle", id)
+    }
+    if def.businessDay != 0 {
+        return nil, fmt.Errorf("event ID %s has a bd-/bd+ qualifier and cannot be referenced", id)
+    }
+    events, err := definitionEvents(cfg, *def, yearContext, refs)
+    if err != nil {
+        return nil, fmt.Errorf("%s: %w", id, err)
+    }
+    var occurrences []Event
+    for _, ev := range applyEventExceptions(events, refs.exceptions) {
+        if ev.ID == def.key() {
+            occurrences = append(occurrences, ev)
+        }
+    }
+    return occurrences, nil
+}
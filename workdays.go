@@ -0,0 +1,118 @@
+package main
+
+import (
+    "fmt"
+    "strings"
+    "time"
+)
+
+// dateKey normalizes a time to midnight UTC so dates from different sources compare equal.
+func dateKey(t time.Time) time.Time {
+    return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ParseTagList parses a comma-separated list of event types (e.g. "ie,church").
+func ParseTagList(s string) []string {
+    var tags []string
+    for _, part := range strings.Split(s, ",") {
+        if tag := strings.TrimSpace(part); tag != "" {
+            tags = append(tags, tag)
+        }
+    }
+    return tags
+}
+
+// hasTag reports whether eventType is one of tags (case-insensitive).
+func hasTag(tags []string, eventType string) bool {
+    for _, tag := range tags {
+        if strings.EqualFold(tag, eventType) {
+            return true
+        }
+    }
+    return false
+}
+
+// HolidaySet collects the dates of events whose type is one of tags.
+// These are the non-working days on top of the weekend. The first event found for a date is kept.
+func HolidaySet(events []Event, tags []string) map[time.Time]Event {
+    holidays := make(map[time.Time]Event)
+    for _, ev := range events {
+        if !hasTag(tags, ev.Type) {
+            continue
+        }
+        key := dateKey(ev.Date)
+        if _, exists := holidays[key]; !exists {
+            holidays[key] = ev
+        }
+    }
+    return holidays
+}
+
+// IsWorkingDay reports whether date is neither a configured weekend day nor a holiday.
+func IsWorkingDay(cfg Config, date time.Time, holidays map[time.Time]Event) bool {
+    if cfg.WeekendDays[date.Weekday()] {
+        return false
+    }
+    _, isHoliday := holidays[dateKey(date)]
+    return !isHoliday
+}
+
+// MoveToBusinessDays moves the events with a "bd-"/"bd+" qualifier that fall on a non-working day
+// to the previous/next working day. Non-working days are the weekend and the holidays of the
+// types in cfg.HolidayTags.
+func MoveToBusinessDays(cfg Config, events []Event) []Event {
+    if len(cfg.WeekendDays) >= 7 {
+        return events // No working day to move to
+    }
+    var fixed []Event // Holidays must not depend on the events being moved
+    for _, ev := range events {
+        if ev.BusinessDay == 0 {
+            fixed = append(fixed, ev)
+        }
+    }
+    holidays := HolidaySet(fixed, cfg.HolidayTags)
+
+    for i := range events {
+        if events[i].BusinessDay == 0 {
+            continue
+        }
+        d := dateKey(events[i].Date)
+        for !IsWorkingDay(cfg, d, holidays) {
+            d = d.AddDate(0, 0, events[i].BusinessDay)
+        }
+        events[i].Date = d
+    }
+    return events
+}
+
+// CountWorkingDays returns the number of working days between from and to (both inclusive).
+func CountWorkingDays(cfg Config, from, to time.Time, holidays map[time.Time]Event) int {
+    count := 0
+    for d := dateKey(from); !d.After(dateKey(to)); d = d.AddDate(0, 0, 1) {
+        if IsWorkingDay(cfg, d, holidays) {
+            count++
+        }
+    }
+    return count
+}
+
+// AddWorkingDays returns the date n working days after start (or before it if n is negative).
+// The start date itself is not counted.
+func AddWorkingDays(cfg Config, start time.Time, n int, holidays map[time.Time]Event) (time.Time, error) {
+    if len(cfg.WeekendDays) >= 7 {
+        return time.Time{}, fmt.Errorf("every day of the week is a weekend day")
+    }
+    step := 1
+    if n < 0 {
+        step = -1
+        n = -n
+    }
+    d := dateKey(start)
+    for n > 0 {
+        d = d.AddDate(0, 0, step)
+        if IsWorkingDay(cfg, d, holidays) {
+            n--
+        }
+    }
+    return d, nil
+}